@@ -1,9 +1,14 @@
 package goxios
 
 import (
+	"context"
 	"net/http"
 
+	"github.com/drummerzzz/goxios/src/auth/apikey"
+	"github.com/drummerzzz/goxios/src/auth/digest"
+	"github.com/drummerzzz/goxios/src/auth/httpsig"
 	"github.com/drummerzzz/goxios/src/auth/oauth"
+	"github.com/drummerzzz/goxios/src/auth/sigv4"
 	"github.com/drummerzzz/goxios/src/request"
 )
 
@@ -12,13 +17,13 @@ import (
 type OAuthClientCredentialsConfig = oauth.Config[oauth.DefaultTokenResponse]
 
 // WithOAuthClientCredentials define auth default do client via OAuth2 client_credentials.
+// Respostas 401 invalidam o token e a request é repetida uma vez com um token novo.
 func WithOAuthClientCredentials(cfg OAuthClientCredentialsConfig) Option {
 	return func(c *Client) error {
 		if c == nil {
 			return nil
 		}
-		src := oauth.NewTokenSource(c.httpClient, c.logger, cfg)
-		c.defaultAuth = src.Apply
+		useOAuthTokenSource(c, oauth.NewTokenSource(c.httpClient, c.logger, cfg), cfg.BackgroundRefresh)
 		return nil
 	}
 }
@@ -29,12 +34,54 @@ func WithOAuthClientCredentialsCustom[T oauth.TokenResponse](cfg oauth.Config[T]
 		if c == nil {
 			return nil
 		}
-		src := oauth.NewTokenSource(c.httpClient, c.logger, cfg)
-		c.defaultAuth = src.Apply
+		useOAuthTokenSource(c, oauth.NewTokenSource(c.httpClient, c.logger, cfg), cfg.BackgroundRefresh)
 		return nil
 	}
 }
 
+// WithOAuthMultiTenant define auth default do client com um TokenSource por tenant.
+// O tenant é extraído de cada request (por padrão do context, via oauth.WithTenant).
+func WithOAuthMultiTenant[T oauth.TokenResponse](cfg oauth.MultiConfig[T]) Option {
+	return func(c *Client) error {
+		if c == nil {
+			return nil
+		}
+		src := oauth.NewMultiTokenSource(c.httpClient, c.logger, cfg)
+		setDefaultAuth(c, src.Apply, src.HandleUnauthorized, src.Stop)
+		return nil
+	}
+}
+
+func useOAuthTokenSource[T oauth.TokenResponse](c *Client, src *oauth.TokenSource[T], background bool) {
+	var stop func()
+	if background {
+		// A renovação só começa no fim do New, com o transport já configurado, e não
+		// começa se a auth for substituída antes disso.
+		stopped := false
+		c.starters = append(c.starters, func() {
+			if !stopped {
+				src.Start(context.Background())
+			}
+		})
+		stop = func() {
+			stopped = true
+			src.Stop()
+		}
+	}
+	setDefaultAuth(c, src.Apply, src.HandleUnauthorized, stop)
+}
+
+// setDefaultAuth troca a auth default do client. stop encerra recursos em background da
+// auth (ex: renovação de token) e é chamado quando ela é substituída ou no Close.
+func setDefaultAuth(c *Client, auth request.AuthFunc, challenge request.ChallengeFunc, stop func()) {
+	if c.defaultAuthStop != nil {
+		c.defaultAuthStop()
+	}
+	c.defaultAuth = auth
+	c.defaultAuthChallenge = challenge
+	c.defaultAuthStop = stop
+}
+
 // Certificate re-exporta o tipo Certificate do pacote request para conveniência.
 type Certificate = request.Certificate
 
@@ -43,13 +90,94 @@ func WithBasicAuth(username, password string) Option {
 		if c == nil {
 			return nil
 		}
-		c.defaultAuth = func(req *http.Request) error {
+		setDefaultAuth(c, func(req *http.Request) error {
 			if req == nil {
 				return nil
 			}
 			req.SetBasicAuth(username, password)
 			return nil
+		}, nil, nil)
+		return nil
+	}
+}
+
+// WithDigestAuth define auth default do client via HTTP Digest (RFC 7616).
+// A primeira request faz o round trip do challenge 401; as seguintes reutilizam o nonce.
+func WithDigestAuth(username, password string) Option {
+	return func(c *Client) error {
+		if c == nil {
+			return nil
+		}
+		a := digest.New(username, password)
+		setDefaultAuth(c, a.Apply, a.HandleChallenge, nil)
+		return nil
+	}
+}
+
+// AWSSigV4Config re-exporta Config do pacote sigv4 para conveniência.
+type AWSSigV4Config = sigv4.Config
+
+// WithAWSSigV4 assina todas as requests do client com AWS Signature Version 4.
+// A assinatura é aplicada como auth, depois que headers e body estão definidos.
+func WithAWSSigV4(cfg AWSSigV4Config) Option {
+	return func(c *Client) error {
+		if c == nil {
+			return nil
+		}
+		signer, err := sigv4.NewSigner(cfg)
+		if err != nil {
+			return err
 		}
+		setDefaultAuth(c, signer.Apply, nil, nil)
+		return nil
+	}
+}
+
+// HTTPSignatureConfig re-exporta Config do pacote httpsig para conveniência.
+type HTTPSignatureConfig = httpsig.Config
+
+// WithHTTPSignature assina as requests do client com HTTP Message Signatures (RFC 9421),
+// gerando Content-Digest quando o header faz parte dos componentes assinados.
+func WithHTTPSignature(cfg HTTPSignatureConfig) Option {
+	return func(c *Client) error {
+		if c == nil {
+			return nil
+		}
+		signer, err := httpsig.NewSigner(cfg)
+		if err != nil {
+			return err
+		}
+		setDefaultAuth(c, signer.Apply, nil, nil)
+		return nil
+	}
+}
+
+// APIKeyLocation re-exporta Location do pacote apikey para conveniência.
+type APIKeyLocation = apikey.Location
+
+const (
+	APIKeyInHeader = apikey.Header
+	APIKeyInQuery  = apikey.Query
+	APIKeyInCookie = apikey.Cookie
+)
+
+// WithAPIKey envia uma API key fixa no header, query ou cookie informado.
+func WithAPIKey(name, value string, location APIKeyLocation) Option {
+	return WithAPIKeyProvider(name, location, apikey.Static(value))
+}
+
+// WithAPIKeyProvider envia a API key fornecida pelo provider a cada request.
+// Com apikey.Rotating, a key é renovada pelo TTL e respostas 401 forçam uma nova busca.
+func WithAPIKeyProvider(name string, location APIKeyLocation, provider apikey.Provider) Option {
+	return func(c *Client) error {
+		if c == nil {
+			return nil
+		}
+		a, err := apikey.New(name, location, provider)
+		if err != nil {
+			return err
+		}
+		setDefaultAuth(c, a.Apply, a.HandleUnauthorized, nil)
 		return nil
 	}
 }
@@ -60,16 +188,16 @@ func WithBearerToken(token string) Option {
 			return nil
 		}
 		if token == "" {
-			c.defaultAuth = nil
+			setDefaultAuth(c, nil, nil, nil)
 			return nil
 		}
-		c.defaultAuth = func(req *http.Request) error {
+		setDefaultAuth(c, func(req *http.Request) error {
 			if req == nil {
 				return nil
 			}
 			req.Header.Set("Authorization", "Bearer "+token)
 			return nil
-		}
+		}, nil, nil)
 		return nil
 	}
 }
@@ -0,0 +1,191 @@
+package goxios
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	goxios_errors "github.com/drummerzzz/goxios/src/errors"
+	"github.com/drummerzzz/goxios/src/request"
+)
+
+type authPair struct {
+	auth      request.AuthFunc
+	challenge request.ChallengeFunc
+	stop      func()
+}
+
+// ChainAuth combina várias opções de auth, aplicadas em ordem em cada request
+// (ex: OAuth seguido de uma assinatura HTTP, que precisa ver o Authorization final).
+// Em respostas 401, todas as auths com tratamento de challenge são consultadas e a
+// request é repetida se alguma delas pedir.
+func ChainAuth(auths ...Option) Option {
+	return func(c *Client) error {
+		if c == nil {
+			return nil
+		}
+		var pairs []authPair
+		for _, opt := range auths {
+			if opt == nil {
+				continue
+			}
+			p, err := captureAuth(c, opt)
+			if err != nil {
+				return err
+			}
+			pairs = append(pairs, p)
+		}
+
+		auth := func(req *http.Request) error {
+			for _, p := range pairs {
+				if p.auth == nil {
+					continue
+				}
+				if err := p.auth(req); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		challenge := func(req *http.Request, resp *http.Response) (bool, error) {
+			retry := false
+			for _, p := range pairs {
+				if p.challenge == nil {
+					continue
+				}
+				ok, err := p.challenge(req, resp)
+				if err != nil {
+					return false, err
+				}
+				retry = retry || ok
+			}
+			return retry, nil
+		}
+		stop := func() {
+			for _, p := range pairs {
+				if p.stop != nil {
+					p.stop()
+				}
+			}
+		}
+		setDefaultAuth(c, auth, challenge, stop)
+		return nil
+	}
+}
+
+// WithAuthForHost usa a opção de auth apenas para requests ao host informado.
+// Aceita host ("api.x.com"), host:porta ou wildcard ("*.x.com"). Requests para outros
+// hosts usam a auth default do client.
+func WithAuthForHost(host string, auth Option) Option {
+	return func(c *Client) error {
+		if c == nil || auth == nil {
+			return nil
+		}
+		if host == "" {
+			return goxios_errors.ErrEmptyHost
+		}
+		p, err := captureAuth(c, auth)
+		if err != nil {
+			return err
+		}
+		if c.hostAuth == nil {
+			c.hostAuth = make(map[string]authPair)
+		}
+		key := strings.ToLower(host)
+		if old, ok := c.hostAuth[key]; ok && old.stop != nil {
+			old.stop()
+		}
+		c.hostAuth[key] = p
+		return nil
+	}
+}
+
+// captureAuth aplica a opção num client temporário e devolve a auth configurada por ela.
+func captureAuth(c *Client, opt Option) (authPair, error) {
+	scratch := &Client{
+		httpClient:     c.httpClient,
+		transport:      c.transport,
+		defaultHeaders: make(http.Header),
+		logger:         c.logger,
+	}
+	if err := opt(scratch); err != nil {
+		return authPair{}, err
+	}
+	c.closers = append(c.closers, scratch.closers...)
+	c.starters = append(c.starters, scratch.starters...)
+	auth, challenge := scratch.authFuncs()
+	return authPair{auth: auth, challenge: challenge, stop: scratch.stopAuth}, nil
+}
+
+// stopAuth encerra os recursos em background da auth default e das auths por host.
+func (c *Client) stopAuth() {
+	if c.defaultAuthStop != nil {
+		c.defaultAuthStop()
+		c.defaultAuthStop = nil
+	}
+	for _, p := range c.hostAuth {
+		if p.stop != nil {
+			p.stop()
+		}
+	}
+}
+
+// applyAuth guarda a auth efetiva do client, usada por todas as requests. Roda depois de
+// todas as opções, para não remontar as rotas por host a cada request.
+func applyAuth(c *Client) {
+	c.resolvedAuth.auth, c.resolvedAuth.challenge = c.authFuncs()
+}
+
+// authFuncs retorna a auth efetiva do client, roteando por host quando configurado.
+func (c *Client) authFuncs() (request.AuthFunc, request.ChallengeFunc) {
+	if len(c.hostAuth) == 0 {
+		return c.defaultAuth, c.defaultAuthChallenge
+	}
+
+	routes := make(map[string]authPair, len(c.hostAuth))
+	for k, v := range c.hostAuth {
+		routes[k] = v
+	}
+	fallback := authPair{auth: c.defaultAuth, challenge: c.defaultAuthChallenge}
+	pick := func(req *http.Request) authPair {
+		if p, ok := matchHost(routes, req.URL); ok {
+			return p
+		}
+		return fallback
+	}
+
+	auth := func(req *http.Request) error {
+		if p := pick(req); p.auth != nil {
+			return p.auth(req)
+		}
+		return nil
+	}
+	challenge := func(req *http.Request, resp *http.Response) (bool, error) {
+		if p := pick(req); p.challenge != nil {
+			return p.challenge(req, resp)
+		}
+		return false, nil
+	}
+	return auth, challenge
+}
+
+func matchHost(routes map[string]authPair, u *url.URL) (authPair, bool) {
+	host := strings.ToLower(u.Host)
+	if p, ok := routes[host]; ok {
+		return p, true
+	}
+	hostname := strings.ToLower(u.Hostname())
+	if p, ok := routes[hostname]; ok {
+		return p, true
+	}
+	for labels := hostname; ; {
+		_, rest, ok := strings.Cut(labels, ".")
+		if !ok {
+			return authPair{}, false
+		}
+		if p, ok := routes["*."+rest]; ok {
+			return p, true
+		}
+		labels = rest
+	}
+}
@@ -1,14 +1,16 @@
 package goxios
 
 import (
-	"crypto/tls"
 	"encoding/base64"
+	"net"
 	"net/http"
 	"net/url"
 	"os"
 	"time"
 
+	"github.com/drummerzzz/goxios/internal/socks5"
 	"github.com/drummerzzz/goxios/internal/tlsutil"
+	"github.com/drummerzzz/goxios/internal/transportpool"
 	goxios_errors "github.com/drummerzzz/goxios/src/errors"
 	"github.com/drummerzzz/goxios/src/request"
 	"github.com/drummerzzz/goxios/src/response"
@@ -31,6 +33,7 @@ func (c *Client) Request(method, rawURL string) *Request {
 	for k, v := range c.defaultHeaders {
 		h[k] = append([]string(nil), v...)
 	}
+	auth, challenge := c.resolvedAuth.auth, c.resolvedAuth.challenge
 	return &Request{
 		HTTPClient:     c.httpClient,
 		Transport:      c.transport,
@@ -39,7 +42,13 @@ func (c *Client) Request(method, rawURL string) *Request {
 		Method:         method,
 		RawURL:         rawURL,
 		CustomHeaders:  h,
-		Auth:           c.defaultAuth,
+		Auth:           auth,
+		AuthChallenge:  challenge,
+		TransportPool:  c.transportPool,
+		ProxyHeader:    c.proxyHeader,
+		BaseDial:       c.baseDial,
+		Phases:         c.phases,
+		Deadline:       c.deadline,
 		ErrNilClient:   goxios_errors.ErrNilClient,
 		ErrEmptyURL:    goxios_errors.ErrEmptyURL,
 		ErrRelativeURL: goxios_errors.ErrRelativeURL,
@@ -75,12 +84,34 @@ func (c *Client) Options(rawURL string) *Request {
 }
 
 type Client struct {
-	baseURL        *url.URL
-	httpClient     *http.Client
-	transport      *http.Transport
-	defaultHeaders http.Header
-	defaultAuth    request.AuthFunc
-	logger         *zap.Logger
+	baseURL              *url.URL
+	httpClient           *http.Client
+	transport            *http.Transport
+	defaultHeaders       http.Header
+	defaultAuth          request.AuthFunc
+	defaultAuthChallenge request.ChallengeFunc
+	defaultAuthStop      func()
+	starters             []func()
+	hostAuth             map[string]authPair
+	resolvedAuth         authPair
+	logger               *zap.Logger
+	closers              []func()
+
+	rootCAs             [][]byte
+	useSystemRoots      bool
+	legacyProxyInsecure bool
+	pins                map[string]certificatePins
+	transportPool       *transportpool.Pool
+	noProxy             noProxyList
+	proxyHeader         http.Header
+	dial                request.DialFunc
+	netDialer           *net.Dialer
+	unixSocket          string
+	resolve             map[string]string
+	baseDial            request.DialFunc
+	socks               *socks5.Dialer
+	phases              request.PhaseTimeouts
+	deadline            *request.DeadlinePropagation
 }
 
 type Option func(*Client) error
@@ -97,12 +128,14 @@ func New(opts ...Option) (*Client, error) {
 		transport:      tr,
 		defaultHeaders: make(http.Header),
 		logger:         zap.NewNop(),
+		transportPool:  transportpool.New(0, 0),
 	}
 
 	c.defaultHeaders.Set("Accept", "application/json")
 	c.defaultHeaders.Set("Content-Type", "application/json")
 
 	applyProxyFromEnv(c)
+	applySOCKS5ProxyFromEnv(c)
 
 	for _, opt := range opts {
 		if opt == nil {
@@ -113,9 +146,35 @@ func New(opts ...Option) (*Client, error) {
 		}
 	}
 
+	applyProxyBypass(c)
+	applyDialer(c)
+	applyInsecureTLS(c)
+	applyAuth(c)
+
+	for _, start := range c.starters {
+		start()
+	}
+	c.starters = nil
+
 	return c, nil
 }
 
+// Close libera recursos em background do client (ex: renovação de tokens OAuth) e fecha as
+// conexões ociosas. Requests em andamento terminam normalmente.
+func (c *Client) Close() error {
+	if c == nil {
+		return nil
+	}
+	for _, fn := range c.closers {
+		fn()
+	}
+	c.closers = nil
+	c.stopAuth()
+	c.transportPool.Close()
+	c.transport.CloseIdleConnections()
+	return nil
+}
+
 // WithLogger define o logger padrão do client.
 // Se logger for nil, usa zap.NewNop().
 func WithLogger(logger *zap.Logger) Option {
@@ -182,8 +241,11 @@ func WithDefaultHeaders(headers map[string]string) Option {
 	}
 }
 
+// WithProxyURL define o proxy HTTP/HTTPS do client. URLs socks5:// e socks5h:// são
+// encaminhadas para WithSOCKS5Proxy. Substitui um proxy SOCKS5 configurado antes.
 func WithProxyURL(raw string) Option {
 	return func(c *Client) error {
+		c.socks = nil
 		if raw == "" {
 			c.transport.Proxy = nil
 			return nil
@@ -192,11 +254,10 @@ func WithProxyURL(raw string) Option {
 		if err != nil {
 			return err
 		}
-		c.transport.Proxy = http.ProxyURL(parsed)
-
-		if c.transport.TLSClientConfig == nil {
-			c.transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true} //nolint:gosec
+		if socks5.IsSOCKS5(parsed) {
+			return WithSOCKS5Proxy(raw)(c)
 		}
+		c.transport.Proxy = http.ProxyURL(parsed)
 		return nil
 	}
 }
@@ -204,14 +265,16 @@ func WithProxyURL(raw string) Option {
 func WithMTLSFromBase64(certBase64, keyBase64 string) Option {
 	return func(c *Client) error {
 		if certBase64 == "" || keyBase64 == "" {
-			c.transport.TLSClientConfig = nil
+			if c.transport.TLSClientConfig != nil {
+				c.transport.TLSClientConfig.Certificates = nil
+			}
 			return nil
 		}
-		tlsConfig, err := tlsutil.LoadTLSConfigFromBase64(certBase64, keyBase64)
+		cert, err := tlsutil.LoadCertificateFromBase64(certBase64, keyBase64)
 		if err != nil {
 			return err
 		}
-		c.transport.TLSClientConfig = tlsConfig
+		c.transport.TLSClientConfig = tlsutil.WithClientCertificate(c.transport.TLSClientConfig, cert)
 		return nil
 	}
 }
@@ -219,8 +282,8 @@ func WithMTLSFromBase64(certBase64, keyBase64 string) Option {
 func WithMTLSFromFile(certFile, keyFile string) Option {
 	return func(c *Client) error {
 		if certFile == "" || keyFile == "" {
-			c.transport.TLSClientConfig = nil
-			return nil
+			// Remove apenas o certificado de cliente; CAs, pins e ajustes de TLS continuam.
+			return WithMTLSFromBase64("", "")(c)
 		}
 		certBytes, err := os.ReadFile(certFile)
 		if err != nil {
@@ -243,4 +306,8 @@ func applyProxyFromEnv(c *Client) {
 		return
 	}
 	_ = WithProxyURL(proxyURL)(c)
+	_ = WithNoProxy(os.Getenv("GOXIOS_NO_PROXY"))(c)
+	if os.Getenv("GOXIOS_PROXY_LEGACY_INSECURE") == "true" {
+		c.legacyProxyInsecure = true
+	}
 }
@@ -1,6 +1,10 @@
 package goxios
 
 import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -35,3 +39,130 @@ func TestClient_WithMTLS_Nil(t *testing.T) {
 		t.Error("expected TLSClientConfig nil when passing nil cert")
 	}
 }
+
+func TestClient_WithDigestAuth(t *testing.T) {
+	var calls atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "Digest ") {
+			w.Header().Set("WWW-Authenticate", `Digest realm="r", nonce="n", qop="auth", algorithm=SHA-256`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	c, err := New(WithBaseURL(srv.URL), WithDigestAuth("user", "pass"))
+	if err != nil {
+		t.Fatalf("New() errored: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		resp, err := c.Get("/").Do()
+		if err != nil {
+			t.Fatalf("Do() errored: %v", err)
+		}
+		if !resp.Ok() {
+			t.Fatalf("expected 2xx; got %d", resp.StatusCode)
+		}
+	}
+	// 1 challenge + 2 requests autenticadas (nonce reaproveitado na segunda).
+	if calls.Load() != 3 {
+		t.Errorf("expected 3 calls; got %d", calls.Load())
+	}
+}
+
+func TestClient_ChainAuthAndHostRouting(t *testing.T) {
+	c, err := New(
+		WithBasicAuth("fallback", "pass"),
+		WithAuthForHost("api.a.com", WithBearerToken("token-a")),
+		WithAuthForHost("*.b.com", WithAPIKey("X-API-Key", "key-b", APIKeyInHeader)),
+	)
+	if err != nil {
+		t.Fatalf("New() errored: %v", err)
+	}
+
+	apply := func(rawURL string) *http.Request {
+		t.Helper()
+		r := c.Get(rawURL)
+		req, _ := http.NewRequest(http.MethodGet, rawURL, nil)
+		if err := r.Auth(req); err != nil {
+			t.Fatalf("Auth() errored: %v", err)
+		}
+		return req
+	}
+
+	if got := apply("https://api.a.com/x").Header.Get("Authorization"); got != "Bearer token-a" {
+		t.Errorf("expected bearer for api.a.com; got %q", got)
+	}
+	req := apply("https://eu.b.com/x")
+	if req.Header.Get("X-API-Key") != "key-b" || req.Header.Get("Authorization") != "" {
+		t.Errorf("expected only api key for *.b.com; got %v", req.Header)
+	}
+	if _, _, ok := apply("https://other.com/x").BasicAuth(); !ok {
+		t.Error("expected basic auth fallback for other hosts")
+	}
+
+	chained, _ := New(ChainAuth(
+		WithBearerToken("tok"),
+		WithAPIKey("X-Signature", "sig", APIKeyInHeader),
+	))
+	req, _ = http.NewRequest(http.MethodGet, "https://api.a.com/x", nil)
+	if err := chained.Get("https://api.a.com/x").Auth(req); err != nil {
+		t.Fatalf("Auth() errored: %v", err)
+	}
+	if req.Header.Get("Authorization") != "Bearer tok" || req.Header.Get("X-Signature") != "sig" {
+		t.Errorf("expected both chained auths applied; got %v", req.Header)
+	}
+
+	if _, err := New(WithAuthForHost("", WithBearerToken("x"))); err == nil {
+		t.Error("expected error for empty host")
+	}
+}
+
+func TestClient_ReplacingOAuthStopsBackgroundRefresh(t *testing.T) {
+	newTokenServer := func(calls *atomic.Int64) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"access_token":"tok","expires_in":1}`))
+		}))
+	}
+	var firstCalls, secondCalls atomic.Int64
+	first := newTokenServer(&firstCalls)
+	defer first.Close()
+	second := newTokenServer(&secondCalls)
+	defer second.Close()
+
+	cfg := func(tokenURL string) OAuthClientCredentialsConfig {
+		return OAuthClientCredentialsConfig{
+			TokenURL:          tokenURL,
+			ClientID:          "id",
+			ClientSecret:      "secret",
+			RefreshBefore:     100 * time.Millisecond,
+			BackgroundRefresh: true,
+		}
+	}
+	c, err := New(
+		WithOAuthClientCredentials(cfg(first.URL)),
+		WithOAuthClientCredentials(cfg(second.URL)),
+	)
+	if err != nil {
+		t.Fatalf("New() errored: %v", err)
+	}
+	defer c.Close()
+
+	// Stop aguarda a goroutine terminar, então o primeiro token endpoint não recebe mais chamadas.
+	calls := firstCalls.Load()
+	deadline := time.Now().Add(3 * time.Second)
+	for secondCalls.Load() < 2 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if secondCalls.Load() < 2 {
+		t.Fatalf("expected background refresh on the active source; got %d calls", secondCalls.Load())
+	}
+	if firstCalls.Load() != calls {
+		t.Fatalf("replaced source kept refreshing: %d -> %d calls", calls, firstCalls.Load())
+	}
+}
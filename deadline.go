@@ -0,0 +1,38 @@
+package goxios
+
+import (
+	"time"
+
+	goxios_errors "github.com/drummerzzz/goxios/src/errors"
+	"github.com/drummerzzz/goxios/src/request"
+)
+
+// DeadlineFormat re-exporta o formato do header de deadline do pacote request.
+type DeadlineFormat = request.DeadlineFormat
+
+const (
+	DeadlineMillis  = request.DeadlineMillis
+	DeadlineSeconds = request.DeadlineSeconds
+	DeadlineGRPC    = request.DeadlineGRPC
+)
+
+// WithDeadlinePropagation envia em header o tempo restante de cada request, menos margin,
+// para que o servidor desista no mesmo prazo (ex: "X-Request-Timeout" ou "grpc-timeout").
+// O limite vem do deadline do contexto passado ao Do e do timeout total da request.
+// Quando o tempo restante já é menor que margin + 1ms, Do falha sem chamar o servidor com
+// ErrDeadlineBudgetExhausted.
+func WithDeadlinePropagation(header string, margin time.Duration, format DeadlineFormat) Option {
+	return func(c *Client) error {
+		if header == "" {
+			return goxios_errors.ErrEmptyHeaderKey
+		}
+		if margin < 0 {
+			return goxios_errors.ErrInvalidTimeout
+		}
+		if !format.Valid() {
+			return goxios_errors.ErrInvalidDeadlineFormat
+		}
+		c.deadline = &request.DeadlinePropagation{Header: header, Margin: margin, Format: format}
+		return nil
+	}
+}
@@ -0,0 +1,48 @@
+package goxios
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	goxios_errors "github.com/drummerzzz/goxios/src/errors"
+)
+
+func TestClient_WithDeadlinePropagation(t *testing.T) {
+	headers := make(chan string, 2)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers <- r.Header.Get("grpc-timeout")
+	}))
+	defer srv.Close()
+
+	c, err := New(WithDeadlinePropagation("grpc-timeout", 50*time.Millisecond, DeadlineGRPC))
+	if err != nil {
+		t.Fatalf("New() errored: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if _, err := c.Get(srv.URL).Do(ctx); err != nil {
+		t.Fatalf("Do() errored: %v", err)
+	}
+	if h := <-headers; !strings.HasSuffix(h, "u") || len(h) < 2 {
+		t.Fatalf("expected grpc-timeout header; got %q", h)
+	}
+	if _, err := c.Get(srv.URL).NoDeadlinePropagation().Do(ctx); err != nil {
+		t.Fatalf("Do() errored: %v", err)
+	}
+	if h := <-headers; h != "" {
+		t.Fatalf("expected no header with NoDeadlinePropagation; got %q", h)
+	}
+
+	if _, err := New(WithDeadlinePropagation("", 0, DeadlineMillis)); !errors.Is(err, goxios_errors.ErrEmptyHeaderKey) {
+		t.Fatalf("expected ErrEmptyHeaderKey; got %v", err)
+	}
+	if _, err := New(WithDeadlinePropagation("X-Request-Timeout", 0, DeadlineFormat(9))); !errors.Is(err, goxios_errors.ErrInvalidDeadlineFormat) {
+		t.Fatalf("expected ErrInvalidDeadlineFormat; got %v", err)
+	}
+}
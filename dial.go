@@ -0,0 +1,119 @@
+package goxios
+
+import (
+	"context"
+	"net"
+	"strings"
+	"time"
+
+	goxios_errors "github.com/drummerzzz/goxios/src/errors"
+	"github.com/drummerzzz/goxios/src/request"
+)
+
+// WithDialContext define a função que abre as conexões do client (ex: túneis, sockets
+// já abertos, instrumentação). Substitui as opções de WithLocalAddr e WithHappyEyeballsDelay;
+// WithUnixSocket e WithResolveOverride continuam valendo sobre ela.
+func WithDialContext(fn func(ctx context.Context, network, addr string) (net.Conn, error)) Option {
+	return func(c *Client) error {
+		c.dial = fn
+		return nil
+	}
+}
+
+// WithUnixSocket envia todas as conexões para o Unix socket informado, independente do host
+// da URL (ex: Docker em /var/run/docker.sock com URLs "http://docker/v1.43/containers/json").
+func WithUnixSocket(path string) Option {
+	return func(c *Client) error {
+		if path == "" {
+			return goxios_errors.ErrInvalidAddress
+		}
+		c.unixSocket = path
+		return nil
+	}
+}
+
+// WithResolveOverride conecta em addr sempre que o client for abrir hostPort, sem consultar
+// o DNS, como o --resolve do curl. O host da URL continua no Host e no SNI/verificação TLS.
+// addr sem porta usa a porta original (ex: "api.x.com:443" -> "10.0.0.12").
+func WithResolveOverride(hostPort, addr string) Option {
+	return func(c *Client) error {
+		if _, _, err := net.SplitHostPort(hostPort); err != nil {
+			return goxios_errors.ErrInvalidAddress
+		}
+		if addr == "" {
+			return goxios_errors.ErrInvalidAddress
+		}
+		if c.resolve == nil {
+			c.resolve = make(map[string]string)
+		}
+		c.resolve[strings.ToLower(hostPort)] = addr
+		return nil
+	}
+}
+
+// WithLocalAddr faz as conexões saírem pelo IP local informado (ex: uma interface ou IP
+// de saída específico).
+func WithLocalAddr(ip string) Option {
+	return func(c *Client) error {
+		parsed := net.ParseIP(ip)
+		if parsed == nil {
+			return goxios_errors.ErrInvalidAddress
+		}
+		netDialer(c).LocalAddr = &net.TCPAddr{IP: parsed}
+		return nil
+	}
+}
+
+// WithHappyEyeballsDelay define quanto esperar pela conexão IPv6 antes de tentar IPv4
+// em paralelo (RFC 6555). Default do Go: 300ms; valor negativo desabilita o fallback.
+func WithHappyEyeballsDelay(delay time.Duration) Option {
+	return func(c *Client) error {
+		netDialer(c).FallbackDelay = delay
+		return nil
+	}
+}
+
+// netDialer retorna o net.Dialer configurável do client, criando-o se necessário.
+func netDialer(c *Client) *net.Dialer {
+	if c.netDialer == nil {
+		c.netDialer = &net.Dialer{}
+	}
+	return c.netDialer
+}
+
+// baseDial monta o dial do client sem proxy: função customizada ou net.Dialer, com os
+// redirecionamentos de Unix socket e de resolução aplicados.
+func baseDial(c *Client) request.DialFunc {
+	var dial request.DialFunc = c.dial
+	if dial == nil && c.netDialer != nil {
+		dial = c.netDialer.DialContext
+	}
+	if c.unixSocket == "" && len(c.resolve) == 0 {
+		return dial
+	}
+
+	inner := dial
+	if inner == nil {
+		inner = (&net.Dialer{}).DialContext
+	}
+	if c.unixSocket != "" {
+		path := c.unixSocket
+		return func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return inner(ctx, "unix", path)
+		}
+	}
+	overrides := make(map[string]string, len(c.resolve))
+	for k, v := range c.resolve {
+		overrides[k] = v
+	}
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		if target, ok := overrides[strings.ToLower(addr)]; ok {
+			if _, _, err := net.SplitHostPort(target); err != nil {
+				_, port, _ := net.SplitHostPort(addr)
+				target = net.JoinHostPort(target, port)
+			}
+			addr = target
+		}
+		return inner(ctx, network, addr)
+	}
+}
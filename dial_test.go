@@ -0,0 +1,105 @@
+package goxios
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	goxios_errors "github.com/drummerzzz/goxios/src/errors"
+)
+
+func TestClient_WithUnixSocket(t *testing.T) {
+	sock := filepath.Join(t.TempDir(), "app.sock")
+	ln, err := net.Listen("unix", sock)
+	if err != nil {
+		t.Skipf("unix sockets unavailable: %v", err)
+	}
+	srv := &httptest.Server{
+		Listener: ln,
+		Config: &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(r.Host + r.URL.Path))
+		})},
+	}
+	srv.Start()
+	defer srv.Close()
+
+	c, err := New(WithUnixSocket(sock))
+	if err != nil {
+		t.Fatalf("New() errored: %v", err)
+	}
+	resp, err := c.Get("http://docker/v1.43/containers/json").Do()
+	if err != nil {
+		t.Fatalf("Do() errored: %v", err)
+	}
+	body, _ := resp.Json()
+	if string(body) != "docker/v1.43/containers/json" {
+		t.Fatalf("unexpected body: %s", body)
+	}
+}
+
+func TestClient_WithResolveOverride(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Host))
+	}))
+	defer srv.Close()
+	_, port, _ := net.SplitHostPort(srv.Listener.Addr().String())
+
+	c, err := New(WithResolveOverride("api.blue.internal:"+port, "127.0.0.1"))
+	if err != nil {
+		t.Fatalf("New() errored: %v", err)
+	}
+	resp, err := c.Get("http://api.blue.internal:" + port).Do()
+	if err != nil {
+		t.Fatalf("Do() errored: %v", err)
+	}
+	if body, _ := resp.Json(); string(body) != "api.blue.internal:"+port {
+		t.Fatalf("expected original Host header; got %s", body)
+	}
+
+	if _, err := New(WithResolveOverride("no-port", "127.0.0.1")); !errors.Is(err, goxios_errors.ErrInvalidAddress) {
+		t.Fatalf("expected ErrInvalidAddress; got %v", err)
+	}
+}
+
+func TestClient_DialOptions(t *testing.T) {
+	var remote atomic.Value
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		remote.Store(r.RemoteAddr)
+	}))
+	defer srv.Close()
+
+	c, err := New(WithLocalAddr("127.0.0.1"), WithHappyEyeballsDelay(-1))
+	if err != nil {
+		t.Fatalf("New() errored: %v", err)
+	}
+	if c.netDialer.FallbackDelay != -1 {
+		t.Fatal("expected happy eyeballs fallback to be disabled")
+	}
+	if _, err := c.Get(srv.URL).Do(); err != nil {
+		t.Fatalf("Do() errored: %v", err)
+	}
+	if host, _, _ := net.SplitHostPort(remote.Load().(string)); host != "127.0.0.1" {
+		t.Fatalf("expected connection from 127.0.0.1; got %s", host)
+	}
+
+	var dials atomic.Int32
+	c, _ = New(WithDialContext(func(ctx context.Context, network, addr string) (net.Conn, error) {
+		dials.Add(1)
+		return (&net.Dialer{}).DialContext(ctx, network, addr)
+	}))
+	if _, err := c.Get(srv.URL).Do(); err != nil {
+		t.Fatalf("Do() errored: %v", err)
+	}
+	if dials.Load() != 1 {
+		t.Fatalf("expected custom dialer to be used; got %d dials", dials.Load())
+	}
+
+	if _, err := New(WithLocalAddr("not-an-ip")); !errors.Is(err, goxios_errors.ErrInvalidAddress) {
+		t.Fatalf("expected ErrInvalidAddress; got %v", err)
+	}
+}
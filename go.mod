@@ -5,10 +5,12 @@ go 1.24.0
 require (
 	github.com/go-redis/redis/v8 v8.11.5
 	go.uber.org/zap v1.27.1
+	software.sslmate.com/src/go-pkcs12 v0.7.3
 )
 
 require (
 	github.com/cespare/xxhash/v2 v2.1.2 // indirect
 	github.com/dgryski/go-rendezvous v0.0.0-20200823014737-9f7001d12a5f // indirect
 	go.uber.org/multierr v1.10.0 // indirect
+	golang.org/x/crypto v0.11.0 // indirect
 )
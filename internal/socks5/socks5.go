@@ -0,0 +1,271 @@
+// Package socks5 implementa o lado cliente do SOCKS5 (RFC 1928) com autenticação
+// usuário/senha (RFC 1929), apenas com o comando CONNECT.
+package socks5
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	goxios_errors "github.com/drummerzzz/goxios/src/errors"
+)
+
+const (
+	version5 = 0x05
+
+	methodNoAuth   = 0x00
+	methodUserPass = 0x02
+
+	userPassVersion = 0x01
+
+	cmdConnect = 0x01
+
+	atypIPv4   = 0x01
+	atypDomain = 0x03
+	atypIPv6   = 0x04
+)
+
+var (
+	// ErrAuthFailed indica que o proxy recusou as credenciais.
+	ErrAuthFailed = errors.New("socks5: authentication failed")
+	// ErrNoAcceptableMethod indica que o proxy não aceita nenhum método oferecido
+	// (ex: exige usuário/senha e nenhum foi configurado).
+	ErrNoAcceptableMethod = errors.New("socks5: no acceptable authentication method")
+)
+
+var replyMessages = map[byte]string{
+	0x01: "general SOCKS server failure",
+	0x02: "connection not allowed by ruleset",
+	0x03: "network unreachable",
+	0x04: "host unreachable",
+	0x05: "connection refused",
+	0x06: "TTL expired",
+	0x07: "command not supported",
+	0x08: "address type not supported",
+}
+
+// Dialer abre conexões TCP através de um proxy SOCKS5.
+type Dialer struct {
+	// ProxyAddr é o host:porta do proxy.
+	ProxyAddr string
+	// Username e Password habilitam a autenticação RFC 1929 quando Username não é vazio.
+	Username string
+	Password string
+	// RemoteDNS envia o hostname para o proxy resolver (socks5h). Se false, o host é
+	// resolvido localmente e o proxy recebe o IP (socks5).
+	RemoteDNS bool
+
+	// Forward abre a conexão até o proxy. Se nil, usa net.Dialer.
+	Forward func(ctx context.Context, network, addr string) (net.Conn, error)
+	// Resolver é usado quando RemoteDNS é false. Se nil, usa net.DefaultResolver.
+	Resolver *net.Resolver
+}
+
+// FromURL cria um Dialer a partir de "socks5://[user:senha@]host[:porta]" (DNS local) ou
+// "socks5h://..." (DNS pelo proxy). A porta default é 1080.
+func FromURL(u *url.URL) (*Dialer, error) {
+	scheme := strings.ToLower(u.Scheme)
+	if scheme != "socks5" && scheme != "socks5h" {
+		return nil, goxios_errors.ErrUnsupportedProxyScheme
+	}
+	if u.Host == "" {
+		return nil, goxios_errors.ErrEmptyHost
+	}
+	addr := u.Host
+	if u.Port() == "" {
+		addr += ":1080"
+	}
+	d := &Dialer{ProxyAddr: addr, RemoteDNS: scheme == "socks5h"}
+	if u.User != nil {
+		d.Username = u.User.Username()
+		d.Password, _ = u.User.Password()
+	}
+	return d, nil
+}
+
+// IsSOCKS5 indica se a URL usa o esquema socks5 ou socks5h.
+func IsSOCKS5(u *url.URL) bool {
+	scheme := strings.ToLower(u.Scheme)
+	return scheme == "socks5" || scheme == "socks5h"
+}
+
+// DialContext conecta em addr através do proxy. Apenas redes TCP são suportadas.
+func (d *Dialer) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	switch network {
+	case "tcp", "tcp4", "tcp6":
+	default:
+		return nil, errors.New("socks5: unsupported network " + network)
+	}
+	host, portStr, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+	port, err := strconv.ParseUint(portStr, 10, 16)
+	if err != nil {
+		return nil, errors.New("socks5: invalid port " + portStr)
+	}
+
+	if !d.RemoteDNS && net.ParseIP(host) == nil {
+		resolver := d.Resolver
+		if resolver == nil {
+			resolver = net.DefaultResolver
+		}
+		ips, err := resolver.LookupIPAddr(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+		if len(ips) == 0 {
+			return nil, errors.New("socks5: no addresses for " + host)
+		}
+		host = ips[0].IP.String()
+	}
+
+	forward := d.Forward
+	if forward == nil {
+		forward = (&net.Dialer{}).DialContext
+	}
+	conn, err := forward(ctx, "tcp", d.ProxyAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := d.handshake(ctx, conn, host, uint16(port)); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return conn, nil
+}
+
+// handshake negocia a autenticação e o CONNECT respeitando o deadline/cancelamento de ctx.
+func (d *Dialer) handshake(ctx context.Context, conn net.Conn, host string, port uint16) (err error) {
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+		defer conn.SetDeadline(time.Time{})
+	}
+	if ctx.Done() != nil {
+		done := make(chan struct{})
+		defer close(done)
+		go func() {
+			select {
+			case <-ctx.Done():
+				// Desbloqueia leituras/escritas pendentes.
+				conn.SetDeadline(time.Unix(1, 0))
+			case <-done:
+			}
+		}()
+		defer func() {
+			if ctx.Err() != nil {
+				err = ctx.Err()
+				return
+			}
+			// O deadline da conexão pode expirar um instante antes do contexto.
+			var ne net.Error
+			if deadline, ok := ctx.Deadline(); ok && errors.As(err, &ne) && ne.Timeout() && !time.Now().Before(deadline) {
+				err = context.DeadlineExceeded
+			}
+		}()
+	}
+
+	methods := []byte{methodNoAuth}
+	if d.Username != "" {
+		methods = []byte{methodNoAuth, methodUserPass}
+	}
+	if _, err := conn.Write(append([]byte{version5, byte(len(methods))}, methods...)); err != nil {
+		return err
+	}
+	var reply [2]byte
+	if _, err := io.ReadFull(conn, reply[:]); err != nil {
+		return err
+	}
+	if reply[0] != version5 {
+		return errors.New("socks5: unexpected protocol version from proxy")
+	}
+	switch reply[1] {
+	case methodNoAuth:
+	case methodUserPass:
+		if d.Username == "" {
+			return ErrNoAcceptableMethod
+		}
+		if err := d.authenticate(conn); err != nil {
+			return err
+		}
+	default:
+		return ErrNoAcceptableMethod
+	}
+
+	req := []byte{version5, cmdConnect, 0x00}
+	if ip := net.ParseIP(host); ip != nil {
+		if ip4 := ip.To4(); ip4 != nil {
+			req = append(append(req, atypIPv4), ip4...)
+		} else {
+			req = append(append(req, atypIPv6), ip.To16()...)
+		}
+	} else {
+		if len(host) > 255 {
+			return errors.New("socks5: hostname too long")
+		}
+		req = append(append(req, atypDomain, byte(len(host))), host...)
+	}
+	req = binary.BigEndian.AppendUint16(req, port)
+	if _, err := conn.Write(req); err != nil {
+		return err
+	}
+
+	var head [4]byte
+	if _, err := io.ReadFull(conn, head[:]); err != nil {
+		return err
+	}
+	if head[1] != 0x00 {
+		msg, ok := replyMessages[head[1]]
+		if !ok {
+			msg = "unknown error " + strconv.Itoa(int(head[1]))
+		}
+		return errors.New("socks5: connect failed: " + msg)
+	}
+
+	// Descarta o endereço de bind (BND.ADDR + BND.PORT).
+	var skip int
+	switch head[3] {
+	case atypIPv4:
+		skip = net.IPv4len + 2
+	case atypIPv6:
+		skip = net.IPv6len + 2
+	case atypDomain:
+		var l [1]byte
+		if _, err := io.ReadFull(conn, l[:]); err != nil {
+			return err
+		}
+		skip = int(l[0]) + 2
+	default:
+		return errors.New("socks5: unexpected address type in reply")
+	}
+	_, err = io.ReadFull(conn, make([]byte, skip))
+	return err
+}
+
+func (d *Dialer) authenticate(conn net.Conn) error {
+	if len(d.Username) > 255 || len(d.Password) > 255 {
+		return errors.New("socks5: username or password too long")
+	}
+	msg := []byte{userPassVersion, byte(len(d.Username))}
+	msg = append(msg, d.Username...)
+	msg = append(msg, byte(len(d.Password)))
+	msg = append(msg, d.Password...)
+	if _, err := conn.Write(msg); err != nil {
+		return err
+	}
+	var reply [2]byte
+	if _, err := io.ReadFull(conn, reply[:]); err != nil {
+		return err
+	}
+	if reply[1] != 0x00 {
+		return ErrAuthFailed
+	}
+	return nil
+}
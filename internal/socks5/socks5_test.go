@@ -0,0 +1,205 @@
+package socks5
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// fakeProxy é um servidor SOCKS5 mínimo que registra o destino pedido e conecta nele.
+type fakeProxy struct {
+	ln       net.Listener
+	user     string
+	pass     string
+	requests chan string
+}
+
+func newFakeProxy(t *testing.T, user, pass string) *fakeProxy {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := &fakeProxy{ln: ln, user: user, pass: pass, requests: make(chan string, 10)}
+	t.Cleanup(func() { ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go p.serve(conn)
+		}
+	}()
+	return p
+}
+
+func (p *fakeProxy) serve(conn net.Conn) {
+	defer conn.Close()
+	head := make([]byte, 2)
+	io.ReadFull(conn, head)
+	methods := make([]byte, head[1])
+	io.ReadFull(conn, methods)
+
+	if p.user != "" {
+		conn.Write([]byte{version5, methodUserPass})
+		b := make([]byte, 2)
+		io.ReadFull(conn, b)
+		user := make([]byte, b[1])
+		io.ReadFull(conn, user)
+		io.ReadFull(conn, b[:1])
+		pass := make([]byte, b[0])
+		io.ReadFull(conn, pass)
+		if string(user) != p.user || string(pass) != p.pass {
+			conn.Write([]byte{userPassVersion, 0x01})
+			return
+		}
+		conn.Write([]byte{userPassVersion, 0x00})
+	} else {
+		conn.Write([]byte{version5, methodNoAuth})
+	}
+
+	req := make([]byte, 4)
+	io.ReadFull(conn, req)
+	var host string
+	switch req[3] {
+	case atypIPv4:
+		ip := make([]byte, 4)
+		io.ReadFull(conn, ip)
+		host = net.IP(ip).String()
+	case atypDomain:
+		l := make([]byte, 1)
+		io.ReadFull(conn, l)
+		name := make([]byte, l[0])
+		io.ReadFull(conn, name)
+		host = string(name)
+	}
+	portB := make([]byte, 2)
+	io.ReadFull(conn, portB)
+	target := net.JoinHostPort(host, strconv.Itoa(int(binary.BigEndian.Uint16(portB))))
+	p.requests <- target
+
+	// "localhost" é resolvido pelo proxy, simulando DNS remoto.
+	upstream, err := net.Dial("tcp", target)
+	if err != nil {
+		conn.Write([]byte{version5, 0x05, 0x00, atypIPv4, 0, 0, 0, 0, 0, 0})
+		return
+	}
+	defer upstream.Close()
+	conn.Write([]byte{version5, 0x00, 0x00, atypIPv4, 127, 0, 0, 1, 0, 0})
+	go io.Copy(upstream, conn)
+	io.Copy(conn, upstream)
+}
+
+func newEchoServer(t *testing.T) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				io.Copy(conn, conn)
+			}()
+		}
+	}()
+	return ln.Addr().String()
+}
+
+func roundTrip(t *testing.T, conn net.Conn) {
+	t.Helper()
+	defer conn.Close()
+	conn.Write([]byte("ping"))
+	buf := make([]byte, 4)
+	if _, err := io.ReadFull(conn, buf); err != nil || string(buf) != "ping" {
+		t.Fatalf("expected echo through proxy; got %q err=%v", buf, err)
+	}
+}
+
+func TestDialer_UserPassAndRemoteDNS(t *testing.T) {
+	echo := newEchoServer(t)
+	_, port, _ := net.SplitHostPort(echo)
+	proxy := newFakeProxy(t, "alice", "s3cret")
+
+	d := &Dialer{ProxyAddr: proxy.ln.Addr().String(), Username: "alice", Password: "s3cret", RemoteDNS: true}
+	conn, err := d.DialContext(context.Background(), "tcp", net.JoinHostPort("localhost", port))
+	if err != nil {
+		t.Fatalf("DialContext() err=%v", err)
+	}
+	roundTrip(t, conn)
+	if got := <-proxy.requests; got != net.JoinHostPort("localhost", port) {
+		t.Fatalf("expected hostname sent to proxy; got %s", got)
+	}
+
+	d.Password = "wrong"
+	if _, err := d.DialContext(context.Background(), "tcp", echo); !errors.Is(err, ErrAuthFailed) {
+		t.Fatalf("expected ErrAuthFailed; got %v", err)
+	}
+	d.Username = ""
+	if _, err := d.DialContext(context.Background(), "tcp", echo); !errors.Is(err, ErrNoAcceptableMethod) {
+		t.Fatalf("expected ErrNoAcceptableMethod; got %v", err)
+	}
+}
+
+func TestDialer_LocalDNS(t *testing.T) {
+	echo := newEchoServer(t)
+	_, port, _ := net.SplitHostPort(echo)
+	proxy := newFakeProxy(t, "", "")
+
+	d := &Dialer{ProxyAddr: proxy.ln.Addr().String()}
+	conn, err := d.DialContext(context.Background(), "tcp", net.JoinHostPort("localhost", port))
+	if err != nil {
+		t.Fatalf("DialContext() err=%v", err)
+	}
+	roundTrip(t, conn)
+	got := <-proxy.requests
+	if host, _, _ := net.SplitHostPort(got); net.ParseIP(host) == nil {
+		t.Fatalf("expected resolved IP sent to proxy; got %s", got)
+	}
+}
+
+func TestDialer_ConnectFailureAndCancel(t *testing.T) {
+	proxy := newFakeProxy(t, "", "")
+	d := &Dialer{ProxyAddr: proxy.ln.Addr().String()}
+
+	// Porta sem listener: o proxy responde "connection refused".
+	ln, _ := net.Listen("tcp", "127.0.0.1:0")
+	closed := ln.Addr().String()
+	ln.Close()
+	if _, err := d.DialContext(context.Background(), "tcp", closed); err == nil {
+		t.Fatal("expected connect failure")
+	}
+
+	// Proxy que nunca responde: o contexto encerra o handshake.
+	silent, _ := net.Listen("tcp", "127.0.0.1:0")
+	defer silent.Close()
+	go func() {
+		conn, err := silent.Accept()
+		if err == nil {
+			defer conn.Close()
+			time.Sleep(time.Second)
+		}
+	}()
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	d = &Dialer{ProxyAddr: silent.Addr().String()}
+	if _, err := d.DialContext(ctx, "tcp", closed); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded; got %v", err)
+	}
+
+	if _, err := d.DialContext(context.Background(), "udp", closed); err == nil {
+		t.Fatal("expected error for udp")
+	}
+}
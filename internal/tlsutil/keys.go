@@ -0,0 +1,234 @@
+package tlsutil
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/des"
+	"crypto/pbkdf2"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"hash"
+
+	goxios_errors "github.com/drummerzzz/goxios/src/errors"
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+var (
+	oidPBES2      = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 13}
+	oidPBKDF2     = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 12}
+	oidHMACSHA1   = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 7}
+	oidHMACSHA256 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 9}
+	oidHMACSHA384 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 10}
+	oidHMACSHA512 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 11}
+	oidAES128CBC  = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 2}
+	oidAES192CBC  = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 22}
+	oidAES256CBC  = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 42}
+	oidDESEDE3CBC = asn1.ObjectIdentifier{1, 2, 840, 113549, 3, 7}
+)
+
+type encryptedPrivateKeyInfo struct {
+	Algorithm     pkix.AlgorithmIdentifier
+	EncryptedData []byte
+}
+
+type pbes2Params struct {
+	KeyDerivationFunc pkix.AlgorithmIdentifier
+	EncryptionScheme  pkix.AlgorithmIdentifier
+}
+
+type pbkdf2Params struct {
+	Salt       []byte
+	Iterations int
+	KeyLength  int                      `asn1:"optional"`
+	PRF        pkix.AlgorithmIdentifier `asn1:"optional"`
+}
+
+// ParseKeyPair monta o certificado de cliente a partir de PEMs. certPEM pode conter a
+// cadeia completa (leaf seguido dos intermediários). Chaves "ENCRYPTED PRIVATE KEY"
+// (PKCS#8 com PBES2) são decifradas com password.
+func ParseKeyPair(certPEM, keyPEM []byte, password string) (tls.Certificate, error) {
+	keyPEM, err := decryptKeyPEM(keyPEM, password)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	return tls.X509KeyPair(certPEM, keyPEM)
+}
+
+// LoadPKCS12 carrega um bundle PKCS#12 (.p12/.pfx) protegido por senha. Os certificados
+// intermediários do bundle são enviados junto com o leaf; CAs raiz são ignoradas.
+func LoadPKCS12(data []byte, password string) (tls.Certificate, error) {
+	key, leaf, caCerts, err := pkcs12.DecodeChain(data, password)
+	if err != nil {
+		if errors.Is(err, pkcs12.ErrIncorrectPassword) {
+			return tls.Certificate{}, goxios_errors.ErrIncorrectKeyPassword
+		}
+		return tls.Certificate{}, err
+	}
+	cert := tls.Certificate{
+		Certificate: [][]byte{leaf.Raw},
+		PrivateKey:  key,
+		Leaf:        leaf,
+	}
+	for _, ca := range caCerts {
+		if isSelfSigned(ca) {
+			continue
+		}
+		cert.Certificate = append(cert.Certificate, ca.Raw)
+	}
+	return cert, nil
+}
+
+// AppendIntermediates adiciona os certificados PEM à cadeia enviada no handshake,
+// para identidades cujos intermediários vêm num arquivo separado.
+func AppendIntermediates(cert *tls.Certificate, chainPEM []byte) error {
+	found := false
+	for rest := chainPEM; ; {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		if _, err := x509.ParseCertificate(block.Bytes); err != nil {
+			return err
+		}
+		cert.Certificate = append(cert.Certificate, block.Bytes)
+		found = true
+	}
+	if !found {
+		return errors.New("no valid certificates found in chain PEM")
+	}
+	return nil
+}
+
+func isSelfSigned(cert *x509.Certificate) bool {
+	return bytes.Equal(cert.RawIssuer, cert.RawSubject) && cert.CheckSignatureFrom(cert) == nil
+}
+
+// decryptKeyPEM devolve keyPEM com a chave decifrada quando ela está criptografada.
+func decryptKeyPEM(keyPEM []byte, password string) ([]byte, error) {
+	var out []byte
+	changed := false
+	for rest := keyPEM; ; {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		switch {
+		case block.Type == "ENCRYPTED PRIVATE KEY":
+			if password == "" {
+				return nil, goxios_errors.ErrKeyPasswordRequired
+			}
+			der, err := decryptPKCS8(block.Bytes, password)
+			if err != nil {
+				return nil, err
+			}
+			block = &pem.Block{Type: "PRIVATE KEY", Bytes: der}
+			changed = true
+		case block.Headers["Proc-Type"] == "4,ENCRYPTED":
+			return nil, errors.New("legacy encrypted PEM keys are not supported; convert to PKCS#8 (openssl pkcs8 -topk8)")
+		}
+		out = append(out, pem.EncodeToMemory(block)...)
+	}
+	if !changed {
+		return keyPEM, nil
+	}
+	return out, nil
+}
+
+// decryptPKCS8 decifra um EncryptedPrivateKeyInfo (RFC 5958) com PBES2/PBKDF2 (RFC 8018).
+func decryptPKCS8(der []byte, password string) ([]byte, error) {
+	var info encryptedPrivateKeyInfo
+	if _, err := asn1.Unmarshal(der, &info); err != nil {
+		return nil, err
+	}
+	if !info.Algorithm.Algorithm.Equal(oidPBES2) {
+		return nil, errors.New("unsupported private key encryption (only PBES2 is supported)")
+	}
+	var params pbes2Params
+	if _, err := asn1.Unmarshal(info.Algorithm.Parameters.FullBytes, &params); err != nil {
+		return nil, err
+	}
+	if !params.KeyDerivationFunc.Algorithm.Equal(oidPBKDF2) {
+		return nil, errors.New("unsupported key derivation function (only PBKDF2 is supported)")
+	}
+	var kdf pbkdf2Params
+	if _, err := asn1.Unmarshal(params.KeyDerivationFunc.Parameters.FullBytes, &kdf); err != nil {
+		return nil, err
+	}
+
+	var prf func() hash.Hash
+	switch alg := kdf.PRF.Algorithm; {
+	case len(alg) == 0 || alg.Equal(oidHMACSHA1):
+		prf = sha1.New
+	case alg.Equal(oidHMACSHA256):
+		prf = sha256.New
+	case alg.Equal(oidHMACSHA384):
+		prf = sha512.New384
+	case alg.Equal(oidHMACSHA512):
+		prf = sha512.New
+	default:
+		return nil, errors.New("unsupported PBKDF2 PRF")
+	}
+
+	var keyLen int
+	var newCipher func(key []byte) (cipher.Block, error)
+	switch alg := params.EncryptionScheme.Algorithm; {
+	case alg.Equal(oidAES128CBC):
+		keyLen, newCipher = 16, aes.NewCipher
+	case alg.Equal(oidAES192CBC):
+		keyLen, newCipher = 24, aes.NewCipher
+	case alg.Equal(oidAES256CBC):
+		keyLen, newCipher = 32, aes.NewCipher
+	case alg.Equal(oidDESEDE3CBC):
+		keyLen, newCipher = 24, des.NewTripleDESCipher
+	default:
+		return nil, errors.New("unsupported private key cipher")
+	}
+	var iv []byte
+	if _, err := asn1.Unmarshal(params.EncryptionScheme.Parameters.FullBytes, &iv); err != nil {
+		return nil, err
+	}
+
+	key, err := pbkdf2.Key(prf, password, kdf.Salt, kdf.Iterations, keyLen)
+	if err != nil {
+		return nil, err
+	}
+	block, err := newCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	data := info.EncryptedData
+	if len(iv) != block.BlockSize() || len(data) == 0 || len(data)%block.BlockSize() != 0 {
+		return nil, errors.New("malformed encrypted private key")
+	}
+	plain := make([]byte, len(data))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(plain, data)
+
+	// Padding inválido é o sintoma de senha incorreta.
+	pad := int(plain[len(plain)-1])
+	if pad == 0 || pad > block.BlockSize() {
+		return nil, goxios_errors.ErrIncorrectKeyPassword
+	}
+	for _, b := range plain[len(plain)-pad:] {
+		if int(b) != pad {
+			return nil, goxios_errors.ErrIncorrectKeyPassword
+		}
+	}
+	plain = plain[:len(plain)-pad]
+	if _, err := x509.ParsePKCS8PrivateKey(plain); err != nil {
+		return nil, goxios_errors.ErrIncorrectKeyPassword
+	}
+	return plain, nil
+}
@@ -0,0 +1,166 @@
+package tlsutil
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	goxios_errors "github.com/drummerzzz/goxios/src/errors"
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+type testIdentity struct {
+	root, intermediate, leaf *x509.Certificate
+	key                      *ecdsa.PrivateKey
+}
+
+// newTestIdentity gera root -> intermediário -> leaf de cliente.
+func newTestIdentity(t *testing.T) testIdentity {
+	t.Helper()
+	issue := func(tmpl, parent *x509.Certificate, pub any, signer *ecdsa.PrivateKey) *x509.Certificate {
+		der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, pub, signer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cert, _ := x509.ParseCertificate(der)
+		return cert
+	}
+	newKey := func() *ecdsa.PrivateKey {
+		k, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		return k
+	}
+	ca := func(serial int64, cn string) *x509.Certificate {
+		return &x509.Certificate{
+			SerialNumber: big.NewInt(serial), Subject: pkix.Name{CommonName: cn},
+			NotBefore: time.Now().Add(-time.Hour), NotAfter: time.Now().Add(time.Hour),
+			IsCA: true, BasicConstraintsValid: true, KeyUsage: x509.KeyUsageCertSign,
+		}
+	}
+
+	rootKey, interKey, leafKey := newKey(), newKey(), newKey()
+	rootTmpl := ca(1, "root")
+	root := issue(rootTmpl, rootTmpl, &rootKey.PublicKey, rootKey)
+	inter := issue(ca(2, "intermediate"), root, &interKey.PublicKey, rootKey)
+	leaf := issue(&x509.Certificate{
+		SerialNumber: big.NewInt(3), Subject: pkix.Name{CommonName: "client"},
+		NotBefore: time.Now().Add(-time.Hour), NotAfter: time.Now().Add(time.Hour),
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}, inter, &leafKey.PublicKey, interKey)
+	return testIdentity{root: root, intermediate: inter, leaf: leaf, key: leafKey}
+}
+
+func certPEM(certs ...*x509.Certificate) []byte {
+	var out []byte
+	for _, c := range certs {
+		out = append(out, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.Raw})...)
+	}
+	return out
+}
+
+// encryptPKCS8 gera um "ENCRYPTED PRIVATE KEY" com PBES2/PBKDF2-SHA256/AES-256-CBC,
+// equivalente a `openssl pkcs8 -topk8 -v2 aes-256-cbc`.
+func encryptPKCS8(t *testing.T, key any, password string) []byte {
+	t.Helper()
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	salt, iv := make([]byte, 16), make([]byte, aes.BlockSize)
+	rand.Read(salt)
+	rand.Read(iv)
+	dk, _ := pbkdf2.Key(sha256.New, password, salt, 2048, 32)
+	block, _ := aes.NewCipher(dk)
+	pad := aes.BlockSize - len(der)%aes.BlockSize
+	for i := 0; i < pad; i++ {
+		der = append(der, byte(pad))
+	}
+	enc := make([]byte, len(der))
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(enc, der)
+
+	kdf, _ := asn1.Marshal(pbkdf2Params{Salt: salt, Iterations: 2048, PRF: pkix.AlgorithmIdentifier{
+		Algorithm: oidHMACSHA256, Parameters: asn1.NullRawValue,
+	}})
+	ivDER, _ := asn1.Marshal(iv)
+	params, _ := asn1.Marshal(pbes2Params{
+		KeyDerivationFunc: pkix.AlgorithmIdentifier{Algorithm: oidPBKDF2, Parameters: asn1.RawValue{FullBytes: kdf}},
+		EncryptionScheme:  pkix.AlgorithmIdentifier{Algorithm: oidAES256CBC, Parameters: asn1.RawValue{FullBytes: ivDER}},
+	})
+	info, _ := asn1.Marshal(encryptedPrivateKeyInfo{
+		Algorithm:     pkix.AlgorithmIdentifier{Algorithm: oidPBES2, Parameters: asn1.RawValue{FullBytes: params}},
+		EncryptedData: enc,
+	})
+	return pem.EncodeToMemory(&pem.Block{Type: "ENCRYPTED PRIVATE KEY", Bytes: info})
+}
+
+func TestParseKeyPair_EncryptedPKCS8(t *testing.T) {
+	id := newTestIdentity(t)
+	keyPEM := encryptPKCS8(t, id.key, "s3cret")
+	chain := certPEM(id.leaf, id.intermediate)
+
+	cert, err := ParseKeyPair(chain, keyPEM, "s3cret")
+	if err != nil {
+		t.Fatalf("ParseKeyPair() err=%v", err)
+	}
+	if len(cert.Certificate) != 2 {
+		t.Fatalf("expected leaf and intermediate; got %d certs", len(cert.Certificate))
+	}
+
+	if _, err := ParseKeyPair(chain, keyPEM, "wrong"); !errors.Is(err, goxios_errors.ErrIncorrectKeyPassword) {
+		t.Fatalf("expected ErrIncorrectKeyPassword; got %v", err)
+	}
+	if _, err := ParseKeyPair(chain, keyPEM, ""); !errors.Is(err, goxios_errors.ErrKeyPasswordRequired) {
+		t.Fatalf("expected ErrKeyPasswordRequired; got %v", err)
+	}
+}
+
+func TestLoadPKCS12(t *testing.T) {
+	id := newTestIdentity(t)
+	for name, enc := range map[string]*pkcs12.Encoder{"Modern": pkcs12.Modern, "Legacy": pkcs12.LegacyRC2} {
+		t.Run(name, func(t *testing.T) {
+			data, err := enc.Encode(id.key, id.leaf, []*x509.Certificate{id.intermediate, id.root}, "s3cret")
+			if err != nil {
+				t.Fatal(err)
+			}
+			cert, err := LoadPKCS12(data, "s3cret")
+			if err != nil {
+				t.Fatalf("LoadPKCS12() err=%v", err)
+			}
+			if len(cert.Certificate) != 2 || cert.Leaf == nil || cert.Leaf.Subject.CommonName != "client" {
+				t.Fatalf("expected leaf + intermediate without root; got %d certs", len(cert.Certificate))
+			}
+			if _, err := LoadPKCS12(data, "wrong"); !errors.Is(err, goxios_errors.ErrIncorrectKeyPassword) {
+				t.Fatalf("expected ErrIncorrectKeyPassword; got %v", err)
+			}
+		})
+	}
+}
+
+func TestAppendIntermediates(t *testing.T) {
+	id := newTestIdentity(t)
+	keyDER, _ := x509.MarshalPKCS8PrivateKey(id.key)
+	cert, err := ParseKeyPair(certPEM(id.leaf), pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := AppendIntermediates(&cert, certPEM(id.intermediate)); err != nil {
+		t.Fatalf("AppendIntermediates() err=%v", err)
+	}
+	if len(cert.Certificate) != 2 {
+		t.Fatalf("expected 2 certs; got %d", len(cert.Certificate))
+	}
+	if err := AppendIntermediates(&cert, []byte("garbage")); err == nil {
+		t.Fatal("expected error for invalid chain")
+	}
+}
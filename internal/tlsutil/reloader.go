@@ -0,0 +1,126 @@
+package tlsutil
+
+import (
+	"crypto/tls"
+	"os"
+	"sync"
+	"time"
+)
+
+// CertReloader mantém um certificado de cliente carregado do disco e o recarrega quando
+// os arquivos mudam, para uso em tls.Config.GetClientCertificate.
+type CertReloader struct {
+	certFile string
+	keyFile  string
+	onError  func(error)
+
+	mu      sync.RWMutex
+	cert    *tls.Certificate
+	modTime time.Time
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewCertReloader carrega o par certificado/chave. onError recebe falhas de recarga
+// (ex: arquivos sendo reescritos); nesses casos o certificado anterior é mantido.
+func NewCertReloader(certFile, keyFile string, onError func(error)) (*CertReloader, error) {
+	if onError == nil {
+		onError = func(error) {}
+	}
+	r := &CertReloader{certFile: certFile, keyFile: keyFile, onError: onError}
+	if _, err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// GetClientCertificate retorna o certificado atual. Só é chamado em novos handshakes,
+// então conexões e requests em andamento não são afetados pela troca.
+func (r *CertReloader) GetClientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.cert, nil
+}
+
+// Reload recarrega o certificado se algum dos arquivos mudou desde a última leitura.
+// Retorna true quando o certificado foi trocado.
+func (r *CertReloader) Reload() (bool, error) {
+	modTime, err := latestModTime(r.certFile, r.keyFile)
+	if err != nil {
+		return false, err
+	}
+
+	r.mu.RLock()
+	unchanged := r.cert != nil && modTime.Equal(r.modTime)
+	r.mu.RUnlock()
+	if unchanged {
+		return false, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return false, err
+	}
+
+	r.mu.Lock()
+	r.cert = &cert
+	r.modTime = modTime
+	r.mu.Unlock()
+	return true, nil
+}
+
+// Start verifica os arquivos a cada interval numa goroutine. Chamadas repetidas são ignoradas.
+func (r *CertReloader) Start(interval time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.stop != nil {
+		return
+	}
+	r.stop = make(chan struct{})
+	r.done = make(chan struct{})
+	go r.poll(interval, r.stop, r.done)
+}
+
+// Stop encerra a verificação periódica.
+func (r *CertReloader) Stop() {
+	r.mu.Lock()
+	stop, done := r.stop, r.done
+	r.stop, r.done = nil, nil
+	r.mu.Unlock()
+	if stop == nil {
+		return
+	}
+	close(stop)
+	<-done
+}
+
+func (r *CertReloader) poll(interval time.Duration, stop, done chan struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			if _, err := r.Reload(); err != nil {
+				r.onError(err)
+			}
+		}
+	}
+}
+
+func latestModTime(files ...string) (time.Time, error) {
+	var latest time.Time
+	for _, f := range files {
+		info, err := os.Stat(f)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest, nil
+}
@@ -1,34 +1,94 @@
 package tlsutil
 
 import (
+	"crypto/sha256"
 	"crypto/tls"
+	"crypto/x509"
 	"encoding/base64"
 	"errors"
+	"strings"
 )
 
 // LoadTLSConfigFromBase64 carrega uma configuração TLS a partir de certificados codificados em Base64.
 func LoadTLSConfigFromBase64(certBase64, keyBase64 string) (*tls.Config, error) {
+	cert, err := LoadCertificateFromBase64(certBase64, keyBase64)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+// LoadCertificateFromBase64 carrega o par certificado/chave a partir de PEMs codificados em Base64.
+func LoadCertificateFromBase64(certBase64, keyBase64 string) (tls.Certificate, error) {
 	if certBase64 == "" || keyBase64 == "" {
-		return nil, errors.New("empty base64 cert/key")
+		return tls.Certificate{}, errors.New("empty base64 cert/key")
 	}
 
 	certPEM, err := base64.StdEncoding.DecodeString(certBase64)
 	if err != nil {
-		return nil, err
+		return tls.Certificate{}, err
 	}
 	keyPEM, err := base64.StdEncoding.DecodeString(keyBase64)
 	if err != nil {
-		return nil, err
+		return tls.Certificate{}, err
 	}
 
-	cert, err := tls.X509KeyPair(certPEM, keyPEM)
-	if err != nil {
-		return nil, err
+	return tls.X509KeyPair(certPEM, keyPEM)
+}
+
+// WithClientCertificate retorna uma cópia de base (ou uma config nova) com o certificado de cliente,
+// preservando RootCAs e demais ajustes já configurados.
+func WithClientCertificate(base *tls.Config, cert tls.Certificate) *tls.Config {
+	var cfg *tls.Config
+	if base != nil {
+		cfg = base.Clone()
+	} else {
+		cfg = &tls.Config{MinVersion: tls.VersionTLS12}
 	}
+	cfg.Certificates = []tls.Certificate{cert}
+	cfg.GetClientCertificate = nil
+	return cfg
+}
 
-	return &tls.Config{
-		Certificates: []tls.Certificate{cert},
-		MinVersion:   tls.VersionTLS12,
-	}, nil
+// LoadCertPool monta um pool com os certificados PEM informados.
+// Com withSystem, os certificados são adicionados ao pool do sistema.
+func LoadCertPool(withSystem bool, pems ...[]byte) (*x509.CertPool, error) {
+	pool := x509.NewCertPool()
+	if withSystem {
+		sys, err := x509.SystemCertPool()
+		if err != nil {
+			return nil, err
+		}
+		pool = sys
+	}
+	for _, p := range pems {
+		if !pool.AppendCertsFromPEM(p) {
+			return nil, errors.New("no valid certificates found in CA PEM")
+		}
+	}
+	return pool, nil
+}
+
+// SPKIHash retorna o sha256 (base64) do SubjectPublicKeyInfo do certificado, formato usado em pins.
+func SPKIHash(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+	return base64.StdEncoding.EncodeToString(sum[:])
 }
 
+// MatchPins indica se algum certificado da cadeia tem o hash SPKI presente em pins.
+// Pins podem vir com o prefixo "sha256/".
+func MatchPins(chain []*x509.Certificate, pins []string) bool {
+	for _, cert := range chain {
+		h := SPKIHash(cert)
+		for _, p := range pins {
+			if strings.TrimPrefix(p, "sha256/") == h {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -3,11 +3,14 @@ package tlsutil
 import (
 	"crypto/rand"
 	"crypto/rsa"
+	"crypto/tls"
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/base64"
 	"encoding/pem"
 	"math/big"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 )
@@ -87,3 +90,102 @@ func TestLoadTLSConfigFromBase64(t *testing.T) {
 	})
 }
 
+func TestLoadCertPool(t *testing.T) {
+	certBase64, _, err := generateTestCert()
+	if err != nil {
+		t.Fatalf("failed to generate test cert: %v", err)
+	}
+	certPEM, _ := base64.StdEncoding.DecodeString(certBase64)
+
+	t.Run("PrivateCA", func(t *testing.T) {
+		pool, err := LoadCertPool(false, certPEM)
+		if err != nil {
+			t.Fatalf("LoadCertPool failed with error: %v", err)
+		}
+		if pool.Equal(x509.NewCertPool()) {
+			t.Error("expected CA in pool")
+		}
+	})
+
+	t.Run("InvalidPEM", func(t *testing.T) {
+		if _, err := LoadCertPool(false, []byte("not a certificate")); err == nil {
+			t.Error("expected error for invalid CA PEM")
+		}
+	})
+}
+
+func TestWithClientCertificate(t *testing.T) {
+	pool := x509.NewCertPool()
+	base := &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS13}
+
+	cfg := WithClientCertificate(base, tls.Certificate{})
+	if cfg == base {
+		t.Fatal("expected a copy of base config")
+	}
+	if cfg.RootCAs != pool || cfg.MinVersion != tls.VersionTLS13 || len(cfg.Certificates) != 1 {
+		t.Errorf("expected base settings preserved with certificate; got %+v", cfg)
+	}
+	if len(base.Certificates) != 0 {
+		t.Error("base config should not be modified")
+	}
+}
+
+func writeTestCert(t *testing.T, certFile, keyFile string, modTime time.Time) {
+	t.Helper()
+	certBase64, keyBase64, err := generateTestCert()
+	if err != nil {
+		t.Fatalf("failed to generate test cert: %v", err)
+	}
+	for file, b64 := range map[string]string{certFile: certBase64, keyFile: keyBase64} {
+		data, _ := base64.StdEncoding.DecodeString(b64)
+		if err := os.WriteFile(file, data, 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(file, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestCertReloader(t *testing.T) {
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+	start := time.Now().Add(-time.Hour)
+	writeTestCert(t, certFile, keyFile, start)
+
+	r, err := NewCertReloader(certFile, keyFile, nil)
+	if err != nil {
+		t.Fatalf("NewCertReloader() err=%v", err)
+	}
+	first, _ := r.GetClientCertificate(nil)
+
+	if changed, err := r.Reload(); changed || err != nil {
+		t.Fatalf("expected no reload for unchanged files; got changed=%v err=%v", changed, err)
+	}
+
+	writeTestCert(t, certFile, keyFile, start.Add(time.Minute))
+	if changed, err := r.Reload(); !changed || err != nil {
+		t.Fatalf("expected reload; got changed=%v err=%v", changed, err)
+	}
+	second, _ := r.GetClientCertificate(nil)
+	if first == second {
+		t.Fatal("expected certificate to be swapped")
+	}
+
+	// Arquivo inválido (ex: escrita parcial) mantém o certificado atual.
+	if err := os.WriteFile(certFile, []byte("partial"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Reload(); err == nil {
+		t.Fatal("expected error for invalid certificate")
+	}
+	if cur, _ := r.GetClientCertificate(nil); cur != second {
+		t.Fatal("expected previous certificate to be kept")
+	}
+
+	r.Start(10 * time.Millisecond)
+	r.Start(10 * time.Millisecond)
+	r.Stop()
+	r.Stop()
+}
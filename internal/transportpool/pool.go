@@ -0,0 +1,144 @@
+// Package transportpool mantém http.Transports derivados do transport do client
+// (ex: mTLS por request), para reaproveitar conexões e sessões TLS entre requests.
+package transportpool
+
+import (
+	"container/list"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Defaults usados quando o tamanho ou o idle timeout não são informados.
+const (
+	DefaultMaxSize     = 64
+	DefaultIdleTimeout = 5 * time.Minute
+)
+
+// Stats são as métricas acumuladas do pool.
+type Stats struct {
+	// Size é o número de transports no pool.
+	Size int
+	// Hits conta requests que reaproveitaram um transport.
+	Hits uint64
+	// Misses conta transports criados.
+	Misses uint64
+	// Evictions conta transports removidos por ociosidade ou limite de tamanho.
+	Evictions uint64
+}
+
+type entry struct {
+	key      string
+	tr       *http.Transport
+	lastUsed time.Time
+}
+
+// Pool é um cache LRU de transports com remoção por ociosidade. Transports removidos
+// têm as conexões ociosas fechadas; requests em andamento terminam normalmente.
+type Pool struct {
+	maxSize     int
+	idleTimeout time.Duration
+	now         func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List
+	stats   Stats
+}
+
+// New cria um pool. maxSize <= 0 e idleTimeout <= 0 usam os defaults.
+func New(maxSize int, idleTimeout time.Duration) *Pool {
+	if maxSize <= 0 {
+		maxSize = DefaultMaxSize
+	}
+	if idleTimeout <= 0 {
+		idleTimeout = DefaultIdleTimeout
+	}
+	return &Pool{
+		maxSize:     maxSize,
+		idleTimeout: idleTimeout,
+		now:         time.Now,
+		entries:     make(map[string]*list.Element),
+		lru:         list.New(),
+	}
+}
+
+// Get retorna o transport de key, criando-o com build quando não existe.
+// build roda fora do lock; se duas goroutines criarem o mesmo key, uma delas é descartada.
+func (p *Pool) Get(key string, build func() (*http.Transport, error)) (*http.Transport, error) {
+	if tr, ok := p.lookup(key); ok {
+		return tr, nil
+	}
+
+	tr, err := build()
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if el, ok := p.entries[key]; ok {
+		tr.CloseIdleConnections()
+		e := el.Value.(*entry)
+		e.lastUsed = p.now()
+		p.lru.MoveToFront(el)
+		return e.tr, nil
+	}
+	p.stats.Misses++
+	p.entries[key] = p.lru.PushFront(&entry{key: key, tr: tr, lastUsed: p.now()})
+	p.evictLocked()
+	return tr, nil
+}
+
+func (p *Pool) lookup(key string) (*http.Transport, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.evictLocked()
+	el, ok := p.entries[key]
+	if !ok {
+		return nil, false
+	}
+	e := el.Value.(*entry)
+	e.lastUsed = p.now()
+	p.lru.MoveToFront(el)
+	p.stats.Hits++
+	return e.tr, true
+}
+
+// evictLocked remove transports ociosos e, em seguida, os menos usados acima de maxSize.
+func (p *Pool) evictLocked() {
+	cutoff := p.now().Add(-p.idleTimeout)
+	for el := p.lru.Back(); el != nil; el = p.lru.Back() {
+		e := el.Value.(*entry)
+		if len(p.entries) <= p.maxSize && e.lastUsed.After(cutoff) {
+			return
+		}
+		p.removeLocked(el)
+		p.stats.Evictions++
+	}
+}
+
+func (p *Pool) removeLocked(el *list.Element) {
+	e := el.Value.(*entry)
+	p.lru.Remove(el)
+	delete(p.entries, e.key)
+	e.tr.CloseIdleConnections()
+}
+
+// Stats retorna as métricas atuais.
+func (p *Pool) Stats() Stats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	s := p.stats
+	s.Size = len(p.entries)
+	return s
+}
+
+// Close fecha as conexões ociosas e esvazia o pool.
+func (p *Pool) Close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for el := p.lru.Back(); el != nil; el = p.lru.Back() {
+		p.removeLocked(el)
+	}
+}
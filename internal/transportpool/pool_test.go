@@ -0,0 +1,66 @@
+package transportpool
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestPool_HitsMissesAndLRU(t *testing.T) {
+	p := New(2, time.Minute)
+	builds := 0
+	build := func() (*http.Transport, error) {
+		builds++
+		return &http.Transport{}, nil
+	}
+
+	a, _ := p.Get("a", build)
+	if again, _ := p.Get("a", build); again != a {
+		t.Fatal("expected same transport for same key")
+	}
+	p.Get("b", build)
+	p.Get("a", build) // "b" passa a ser o menos usado
+	p.Get("c", build)
+
+	s := p.Stats()
+	if s.Size != 2 || s.Hits != 2 || s.Misses != 3 || s.Evictions != 1 {
+		t.Fatalf("unexpected stats: %+v", s)
+	}
+	p.Get("b", build)
+	if builds != 4 {
+		t.Fatalf("expected evicted key to be rebuilt; builds=%d", builds)
+	}
+}
+
+func TestPool_IdleEviction(t *testing.T) {
+	p := New(10, time.Minute)
+	now := time.Unix(1000, 0)
+	p.now = func() time.Time { return now }
+	build := func() (*http.Transport, error) { return &http.Transport{}, nil }
+
+	a, _ := p.Get("a", build)
+	now = now.Add(2 * time.Minute)
+	if b, _ := p.Get("a", build); b == a {
+		t.Fatal("expected idle transport to be evicted")
+	}
+	if s := p.Stats(); s.Evictions != 1 || s.Size != 1 {
+		t.Fatalf("unexpected stats: %+v", s)
+	}
+
+	p.Close()
+	if s := p.Stats(); s.Size != 0 {
+		t.Fatalf("expected empty pool after Close; got %+v", s)
+	}
+}
+
+func TestPool_BuildErrorIsNotCached(t *testing.T) {
+	p := New(0, 0)
+	boom := errors.New("boom")
+	if _, err := p.Get("a", func() (*http.Transport, error) { return nil, boom }); !errors.Is(err, boom) {
+		t.Fatalf("expected build error; got %v", err)
+	}
+	if s := p.Stats(); s.Size != 0 || s.Misses != 0 {
+		t.Fatalf("unexpected stats: %+v", s)
+	}
+}
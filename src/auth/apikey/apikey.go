@@ -0,0 +1,182 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Location define onde a API key é enviada.
+type Location int
+
+const (
+	// Header envia a key num header (ex: X-API-Key).
+	Header Location = iota
+	// Query envia a key como parâmetro da URL (ex: ?api_key=).
+	Query
+	// Cookie envia a key num cookie.
+	Cookie
+)
+
+// ErrInvalidLocation indica uma Location desconhecida.
+var ErrInvalidLocation = errors.New("apikey: invalid location")
+
+// Provider fornece a API key atual.
+type Provider interface {
+	Key(ctx context.Context) (string, error)
+}
+
+// ProviderFunc adapta uma função para Provider.
+type ProviderFunc func(ctx context.Context) (string, error)
+
+func (f ProviderFunc) Key(ctx context.Context) (string, error) { return f(ctx) }
+
+// Static retorna sempre a mesma key.
+func Static(key string) Provider {
+	return ProviderFunc(func(ctx context.Context) (string, error) { return key, nil })
+}
+
+// Invalidator é implementado por providers que podem descartar a key em cache
+// (ex: quando a API responde 401 após uma rotação). rejected é a key enviada na request
+// recusada; se ela já foi substituída, o provider mantém a key atual.
+type Invalidator interface {
+	Invalidate(rejected string)
+}
+
+// Rotating busca a key numa fonte de segredos (ex: Vault, Secrets Manager) e a mantém
+// em cache por TTL, permitindo rotação sem recriar o client.
+type Rotating struct {
+	fetch func(ctx context.Context) (string, error)
+	ttl   time.Duration
+	now   func() time.Time
+
+	mu        sync.Mutex
+	key       string
+	fetchedAt time.Time
+}
+
+// NewRotating cria um provider com cache. ttl <= 0 mantém a key até Invalidate.
+func NewRotating(fetch func(ctx context.Context) (string, error), ttl time.Duration) *Rotating {
+	return &Rotating{fetch: fetch, ttl: ttl, now: time.Now}
+}
+
+// Key retorna a key em cache ou busca uma nova. Se a busca falhar, a key anterior
+// continua sendo usada.
+func (r *Rotating) Key(ctx context.Context) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.key != "" && (r.ttl <= 0 || r.now().Before(r.fetchedAt.Add(r.ttl))) {
+		return r.key, nil
+	}
+	key, err := r.fetch(ctx)
+	if err != nil {
+		if r.key != "" {
+			return r.key, nil
+		}
+		return "", err
+	}
+	r.key = key
+	r.fetchedAt = r.now()
+	return key, nil
+}
+
+// Invalidate descarta a key em cache se ela ainda for rejected; a próxima chamada a Key
+// busca na fonte. Evita buscas repetidas quando várias requests recebem 401 com a mesma key.
+func (r *Rotating) Invalidate(rejected string) {
+	r.mu.Lock()
+	if r.key == rejected {
+		r.key = ""
+	}
+	r.mu.Unlock()
+}
+
+// Auth aplica a API key na request.
+type Auth struct {
+	name     string
+	location Location
+	provider Provider
+}
+
+// New cria um Auth de API key.
+func New(name string, location Location, provider Provider) (*Auth, error) {
+	if name == "" {
+		return nil, errors.New("apikey: empty name")
+	}
+	if location < Header || location > Cookie {
+		return nil, ErrInvalidLocation
+	}
+	if provider == nil {
+		return nil, errors.New("apikey: nil provider")
+	}
+	return &Auth{name: name, location: location, provider: provider}, nil
+}
+
+// Apply adiciona a key na request conforme a Location.
+func (a *Auth) Apply(req *http.Request) error {
+	if a == nil || req == nil {
+		return nil
+	}
+	key, err := a.provider.Key(req.Context())
+	if err != nil {
+		return err
+	}
+
+	switch a.location {
+	case Header:
+		req.Header.Set(a.name, key)
+	case Query:
+		q := req.URL.Query()
+		q.Set(a.name, key)
+		req.URL.RawQuery = q.Encode()
+	case Cookie:
+		// Remove um cookie anterior com o mesmo nome (ex: retry após rotação).
+		var kept []string
+		for _, c := range req.Cookies() {
+			if c.Name != a.name {
+				kept = append(kept, c.String())
+			}
+		}
+		req.Header.Del("Cookie")
+		if len(kept) > 0 {
+			req.Header.Set("Cookie", strings.Join(kept, "; "))
+		}
+		req.AddCookie(&http.Cookie{Name: a.name, Value: key})
+	}
+	return nil
+}
+
+// HandleUnauthorized invalida a key em cache em respostas 401 quando o provider
+// suporta rotação, para repetir a request com a key atual.
+func (a *Auth) HandleUnauthorized(req *http.Request, resp *http.Response) (bool, error) {
+	if a == nil || resp == nil || resp.StatusCode != http.StatusUnauthorized {
+		return false, nil
+	}
+	inv, ok := a.provider.(Invalidator)
+	if !ok {
+		return false, nil
+	}
+	inv.Invalidate(a.sentKey(req))
+	return true, nil
+}
+
+// sentKey retorna a key enviada na request conforme a Location.
+func (a *Auth) sentKey(req *http.Request) string {
+	if req == nil {
+		return ""
+	}
+	switch a.location {
+	case Header:
+		return req.Header.Get(a.name)
+	case Query:
+		return req.URL.Query().Get(a.name)
+	case Cookie:
+		if c, err := req.Cookie(a.name); err == nil {
+			return c.Value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,117 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestAuth_Locations(t *testing.T) {
+	tests := []struct {
+		location Location
+		check    func(req *http.Request) string
+	}{
+		{Header, func(req *http.Request) string { return req.Header.Get("X-API-Key") }},
+		{Query, func(req *http.Request) string { return req.URL.Query().Get("X-API-Key") }},
+		{Cookie, func(req *http.Request) string {
+			c, _ := req.Cookie("X-API-Key")
+			if c == nil {
+				return ""
+			}
+			return c.Value
+		}},
+	}
+
+	for _, tt := range tests {
+		a, err := New("X-API-Key", tt.location, Static("k1"))
+		if err != nil {
+			t.Fatalf("New() err=%v", err)
+		}
+		req, _ := http.NewRequest(http.MethodGet, "http://example.com/x?page=2", nil)
+		if err := a.Apply(req); err != nil {
+			t.Fatalf("Apply() err=%v", err)
+		}
+		if got := tt.check(req); got != "k1" {
+			t.Errorf("location %d: expected k1; got %q", tt.location, got)
+		}
+		if req.URL.Query().Get("page") != "2" {
+			t.Errorf("location %d: existing query lost: %s", tt.location, req.URL.RawQuery)
+		}
+	}
+
+	if _, err := New("k", Location(42), Static("v")); !errors.Is(err, ErrInvalidLocation) {
+		t.Fatalf("expected ErrInvalidLocation; got %v", err)
+	}
+}
+
+func TestRotating(t *testing.T) {
+	calls := 0
+	fail := false
+	r := NewRotating(func(ctx context.Context) (string, error) {
+		if fail {
+			return "", errors.New("secret store down")
+		}
+		calls++
+		return []string{"", "k1", "k2", "k3"}[calls], nil
+	}, time.Minute)
+
+	now := time.Unix(1000, 0)
+	r.now = func() time.Time { return now }
+
+	if k, _ := r.Key(context.Background()); k != "k1" {
+		t.Fatalf("expected k1; got %s", k)
+	}
+	if k, _ := r.Key(context.Background()); k != "k1" || calls != 1 {
+		t.Fatalf("expected cached k1; got %s calls=%d", k, calls)
+	}
+
+	now = now.Add(2 * time.Minute)
+	if k, _ := r.Key(context.Background()); k != "k2" {
+		t.Fatalf("expected k2 after ttl; got %s", k)
+	}
+
+	a, _ := New("X-API-Key", Header, r)
+	req, _ := http.NewRequest(http.MethodGet, "http://example.com", nil)
+	_ = a.Apply(req)
+	retry, _ := a.HandleUnauthorized(req, &http.Response{StatusCode: http.StatusUnauthorized})
+	if !retry {
+		t.Fatal("expected retry for rotating provider")
+	}
+	if k, _ := r.Key(context.Background()); k != "k3" {
+		t.Fatalf("expected k3 after invalidate; got %s", k)
+	}
+
+	// Fonte indisponível mantém a key atual.
+	fail = true
+	now = now.Add(2 * time.Minute)
+	if k, err := r.Key(context.Background()); k != "k3" || err != nil {
+		t.Fatalf("expected stale k3; got %s err=%v", k, err)
+	}
+}
+
+func TestRotating_InvalidateIgnoresReplacedKey(t *testing.T) {
+	calls := 0
+	r := NewRotating(func(ctx context.Context) (string, error) {
+		calls++
+		return fmt.Sprintf("k%d", calls), nil
+	}, 0)
+	a, _ := New("api_key", Query, r)
+
+	// Duas requests concorrentes enviaram k1 e receberam 401.
+	first, _ := http.NewRequest(http.MethodGet, "http://example.com", nil)
+	second, _ := http.NewRequest(http.MethodGet, "http://example.com", nil)
+	_ = a.Apply(first)
+	_ = a.Apply(second)
+
+	a.HandleUnauthorized(first, &http.Response{StatusCode: http.StatusUnauthorized})
+	if k, _ := r.Key(context.Background()); k != "k2" {
+		t.Fatalf("expected k2 after invalidate; got %s", k)
+	}
+	a.HandleUnauthorized(second, &http.Response{StatusCode: http.StatusUnauthorized})
+	if k, _ := r.Key(context.Background()); k != "k2" || calls != 2 {
+		t.Fatalf("stale 401 must not refetch; got %s calls=%d", k, calls)
+	}
+}
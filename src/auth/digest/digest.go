@@ -0,0 +1,266 @@
+package digest
+
+import (
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/drummerzzz/goxios/src/request"
+)
+
+// algorithms suportados, do mais forte para o mais fraco (ordem de preferência entre challenges).
+var algorithms = []string{"SHA-512-256", "SHA-256", "MD5"}
+
+func newHash(algorithm string) func() hash.Hash {
+	switch strings.TrimSuffix(strings.ToUpper(algorithm), "-SESS") {
+	case "", "MD5":
+		return md5.New
+	case "SHA-256":
+		return sha256.New
+	case "SHA-512-256":
+		return sha512.New512_256
+	}
+	return nil
+}
+
+// challenge guarda os parâmetros do último WWW-Authenticate: Digest recebido.
+type challenge struct {
+	realm     string
+	nonce     string
+	opaque    string
+	algorithm string
+	qop       string
+	nc        uint32
+}
+
+// Auth implementa HTTP Digest Authentication (RFC 7616).
+// O primeiro request recebe o challenge 401 e é repetido; os seguintes reutilizam
+// o nonce com contador incremental, sem round trip extra.
+type Auth struct {
+	username string
+	password string
+
+	mu sync.Mutex
+	ch *challenge
+}
+
+// New cria um Auth Digest com as credenciais informadas.
+func New(username, password string) *Auth {
+	return &Auth{username: username, password: password}
+}
+
+// Apply adiciona o header Authorization quando já existe um challenge em cache.
+func (a *Auth) Apply(req *http.Request) error {
+	if a == nil || req == nil {
+		return nil
+	}
+	a.mu.Lock()
+	if a.ch == nil {
+		a.mu.Unlock()
+		return nil
+	}
+	a.ch.nc++
+	ch := *a.ch
+	a.mu.Unlock()
+
+	var body []byte
+	if ch.qop == "auth-int" {
+		var err error
+		if body, err = request.BodyBytes(req); err != nil {
+			return err
+		}
+	}
+
+	cnonce, err := newCnonce()
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", a.authorization(&ch, req.Method, req.URL.RequestURI(), cnonce, body))
+	return nil
+}
+
+// HandleChallenge processa respostas 401 com WWW-Authenticate: Digest, guardando o nonce
+// e sinalizando que a request deve ser repetida. Não repete quando as credenciais foram
+// recusadas com o mesmo nonce e realm enviados, sem stale=true.
+func (a *Auth) HandleChallenge(req *http.Request, resp *http.Response) (bool, error) {
+	if a == nil || req == nil || resp == nil || resp.StatusCode != http.StatusUnauthorized {
+		return false, nil
+	}
+	params, ok := selectChallenge(resp.Header.Values("WWW-Authenticate"))
+	if !ok {
+		return false, nil
+	}
+
+	ch := &challenge{
+		realm:     params["realm"],
+		nonce:     params["nonce"],
+		opaque:    params["opaque"],
+		algorithm: params["algorithm"],
+		qop:       selectQop(params["qop"]),
+	}
+	// O challenge novo é sempre guardado, para que as próximas requests não insistam num
+	// nonce que o servidor já descartou.
+	a.mu.Lock()
+	a.ch = ch
+	a.mu.Unlock()
+
+	sent, sentDigest := strings.CutPrefix(req.Header.Get("Authorization"), "Digest ")
+	if !sentDigest || strings.EqualFold(params["stale"], "true") {
+		return true, nil
+	}
+	// Com o mesmo nonce e realm, as credenciais foram recusadas: repetir não adianta.
+	prev := parseParams(sent)
+	return prev["nonce"] != ch.nonce || prev["realm"] != ch.realm, nil
+}
+
+func (a *Auth) authorization(ch *challenge, method, uri, cnonce string, body []byte) string {
+	h := newHash(ch.algorithm)
+	hexHash := func(s string) string {
+		d := h()
+		d.Write([]byte(s))
+		return hex.EncodeToString(d.Sum(nil))
+	}
+
+	ha1 := hexHash(a.username + ":" + ch.realm + ":" + a.password)
+	if strings.HasSuffix(strings.ToUpper(ch.algorithm), "-SESS") {
+		ha1 = hexHash(ha1 + ":" + ch.nonce + ":" + cnonce)
+	}
+
+	ha2 := hexHash(method + ":" + uri)
+	if ch.qop == "auth-int" {
+		d := h()
+		d.Write(body)
+		ha2 = hexHash(method + ":" + uri + ":" + hex.EncodeToString(d.Sum(nil)))
+	}
+
+	nc := fmt.Sprintf("%08x", ch.nc)
+	var response string
+	if ch.qop == "" {
+		// Compatibilidade com RFC 2069 (sem qop).
+		response = hexHash(ha1 + ":" + ch.nonce + ":" + ha2)
+	} else {
+		response = hexHash(ha1 + ":" + ch.nonce + ":" + nc + ":" + cnonce + ":" + ch.qop + ":" + ha2)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `Digest username=%s, realm=%s, nonce=%s, uri=%s`, quote(a.username), quote(ch.realm), quote(ch.nonce), quote(uri))
+	if ch.algorithm != "" {
+		fmt.Fprintf(&b, `, algorithm=%s`, ch.algorithm)
+	}
+	fmt.Fprintf(&b, `, response=%s`, quote(response))
+	if ch.opaque != "" {
+		fmt.Fprintf(&b, `, opaque=%s`, quote(ch.opaque))
+	}
+	if ch.qop != "" {
+		fmt.Fprintf(&b, `, qop=%s, nc=%s, cnonce=%s`, ch.qop, nc, quote(cnonce))
+	}
+	return b.String()
+}
+
+// selectChallenge escolhe, entre os headers WWW-Authenticate, o challenge Digest
+// com o algoritmo mais forte suportado.
+func selectChallenge(headers []string) (map[string]string, bool) {
+	var best map[string]string
+	bestRank := len(algorithms)
+	for _, h := range headers {
+		scheme, rest, _ := strings.Cut(strings.TrimSpace(h), " ")
+		if !strings.EqualFold(scheme, "Digest") {
+			continue
+		}
+		params := parseParams(rest)
+		if newHash(params["algorithm"]) == nil || params["nonce"] == "" {
+			continue
+		}
+		alg := strings.TrimSuffix(strings.ToUpper(params["algorithm"]), "-SESS")
+		if alg == "" {
+			alg = "MD5"
+		}
+		for rank, a := range algorithms {
+			if a == alg && rank < bestRank {
+				best, bestRank = params, rank
+			}
+		}
+	}
+	return best, best != nil
+}
+
+// selectQop prefere "auth" e só usa "auth-int" quando é a única opção.
+func selectQop(offered string) string {
+	var authInt bool
+	for _, q := range strings.Split(offered, ",") {
+		switch strings.TrimSpace(q) {
+		case "auth":
+			return "auth"
+		case "auth-int":
+			authInt = true
+		}
+	}
+	if authInt {
+		return "auth-int"
+	}
+	return ""
+}
+
+// parseParams lê a lista de auth-params (chave=valor ou chave="valor") de um challenge.
+func parseParams(s string) map[string]string {
+	params := make(map[string]string)
+	for {
+		s = strings.TrimLeft(s, " ,\t")
+		if s == "" {
+			return params
+		}
+		key, rest, ok := strings.Cut(s, "=")
+		if !ok {
+			return params
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		rest = strings.TrimLeft(rest, " \t")
+
+		var value string
+		if strings.HasPrefix(rest, `"`) {
+			var b strings.Builder
+			i := 1
+			for ; i < len(rest) && rest[i] != '"'; i++ {
+				if rest[i] == '\\' && i+1 < len(rest) {
+					i++
+				}
+				b.WriteByte(rest[i])
+			}
+			value = b.String()
+			if i < len(rest) {
+				i++
+			}
+			s = rest[i:]
+		} else {
+			end := strings.IndexByte(rest, ',')
+			if end < 0 {
+				end = len(rest)
+			}
+			value = strings.TrimSpace(rest[:end])
+			s = rest[end:]
+		}
+		params[key] = value
+	}
+}
+
+var quoteEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// quote monta um quoted-string HTTP.
+func quote(s string) string {
+	return `"` + quoteEscaper.Replace(s) + `"`
+}
+
+func newCnonce() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
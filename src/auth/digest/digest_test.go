@@ -0,0 +1,120 @@
+package digest
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// Vetores do RFC 7616 §3.9.1.
+func TestAuth_RFC7616Vectors(t *testing.T) {
+	a := New("Mufasa", "Circle of Life")
+	tests := []struct {
+		algorithm string
+		response  string
+	}{
+		{"MD5", "8ca523f5e9506fed4657c9700eebdbec"},
+		{"SHA-256", "753927fa0e85d155564e2e272a28d1802ca10daf4496794697cf8db5856cb6c1"},
+	}
+
+	for _, tt := range tests {
+		ch := &challenge{
+			realm:     "http-auth@example.org",
+			nonce:     "7ypf/xlj9XXwfDPEoM4URrv/xwf94BcCAzFZH4GiTo0v",
+			opaque:    "FQhe/qaU925kfnzjCev0ciny7QMkPqMAFRtzCUYo5tdS",
+			algorithm: tt.algorithm,
+			qop:       "auth",
+			nc:        1,
+		}
+		got := a.authorization(ch, http.MethodGet, "/dir/index.html", "f2/wE4q74E6zIJEtWaHKaf5wv/H5QzzpXusqGemxURZJ", nil)
+		if !strings.Contains(got, `response="`+tt.response+`"`) {
+			t.Errorf("%s: unexpected header %s", tt.algorithm, got)
+		}
+		if !strings.Contains(got, "nc=00000001") {
+			t.Errorf("%s: expected nc=00000001 in %s", tt.algorithm, got)
+		}
+	}
+}
+
+func TestAuth_HandleChallenge(t *testing.T) {
+	a := New("user", "pass")
+	req, _ := http.NewRequest(http.MethodGet, "http://example.com/a?b=c", nil)
+
+	resp := &http.Response{StatusCode: http.StatusUnauthorized, Header: http.Header{}}
+	resp.Header.Add("WWW-Authenticate", `Digest realm="r", nonce="n1", qop="auth,auth-int", algorithm=MD5`)
+	resp.Header.Add("WWW-Authenticate", `Digest realm="r", nonce="n2", qop="auth", algorithm=SHA-256`)
+	resp.Header.Add("WWW-Authenticate", `Basic realm="r"`)
+
+	retry, err := a.HandleChallenge(req, resp)
+	if err != nil || !retry {
+		t.Fatalf("expected retry; got retry=%v err=%v", retry, err)
+	}
+	if a.ch.nonce != "n2" || a.ch.algorithm != "SHA-256" {
+		t.Fatalf("expected strongest challenge; got %+v", a.ch)
+	}
+
+	if err := a.Apply(req); err != nil {
+		t.Fatalf("Apply() err=%v", err)
+	}
+	if err := a.Apply(req); err != nil {
+		t.Fatalf("Apply() err=%v", err)
+	}
+	h := req.Header.Get("Authorization")
+	if !strings.Contains(h, "nc=00000002") || !strings.Contains(h, `uri="/a?b=c"`) {
+		t.Fatalf("unexpected Authorization header: %s", h)
+	}
+
+	// Credenciais recusadas sem stale=true não devem gerar novo retry.
+	retry, _ = a.HandleChallenge(req, resp)
+	if retry {
+		t.Fatal("expected no retry when digest credentials were rejected")
+	}
+
+	stale := &http.Response{StatusCode: http.StatusUnauthorized, Header: http.Header{}}
+	stale.Header.Set("WWW-Authenticate", `Digest realm="r", nonce="n3", qop="auth", stale=true`)
+	retry, _ = a.HandleChallenge(req, stale)
+	if !retry || a.ch.nonce != "n3" {
+		t.Fatal("expected retry with new nonce when stale=true")
+	}
+}
+
+func TestAuth_HandleChallenge_RotatedNonce(t *testing.T) {
+	a := New("user", "pass")
+	req, _ := http.NewRequest(http.MethodGet, "http://example.com/a", nil)
+
+	first := &http.Response{StatusCode: http.StatusUnauthorized, Header: http.Header{}}
+	first.Header.Set("WWW-Authenticate", `Digest realm="r", nonce="n1", qop="auth"`)
+	if retry, _ := a.HandleChallenge(req, first); !retry {
+		t.Fatal("expected retry on first challenge")
+	}
+	if err := a.Apply(req); err != nil {
+		t.Fatalf("Apply() err=%v", err)
+	}
+
+	// Servidor trocou o nonce sem stale=true: guarda o novo e repete uma vez.
+	rotated := &http.Response{StatusCode: http.StatusUnauthorized, Header: http.Header{}}
+	rotated.Header.Set("WWW-Authenticate", `Digest realm="r", nonce="n2", qop="auth"`)
+	if retry, _ := a.HandleChallenge(req, rotated); !retry {
+		t.Fatal("expected retry when the nonce changed")
+	}
+	if err := a.Apply(req); err != nil {
+		t.Fatalf("Apply() err=%v", err)
+	}
+	if h := req.Header.Get("Authorization"); !strings.Contains(h, `nonce="n2"`) {
+		t.Fatalf("expected new nonce on later requests; got %s", h)
+	}
+
+	// Outro realm também é guardado e gera retry.
+	realm := &http.Response{StatusCode: http.StatusUnauthorized, Header: http.Header{}}
+	realm.Header.Set("WWW-Authenticate", `Digest realm="r2", nonce="n2", qop="auth"`)
+	if retry, _ := a.HandleChallenge(req, realm); !retry || a.ch.realm != "r2" {
+		t.Fatalf("expected retry and stored realm r2; got %+v", a.ch)
+	}
+}
+
+func TestParseParams(t *testing.T) {
+	got := parseParams(`realm="a, \"b\"", nonce=xyz, qop="auth"`)
+	if got["realm"] != `a, "b"` || got["nonce"] != "xyz" || got["qop"] != "auth" {
+		t.Fatalf("unexpected params: %#v", got)
+	}
+}
@@ -0,0 +1,219 @@
+package httpsig
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/drummerzzz/goxios/src/request"
+)
+
+// DefaultComponents são os componentes assinados quando Config.Components é vazio.
+var DefaultComponents = []string{"@method", "@target-uri", "content-digest"}
+
+// Config contém as configurações de assinatura (RFC 9421).
+type Config struct {
+	// Key assina a signature base. Obrigatório.
+	Key Key
+	// KeyID é enviado no parâmetro keyid.
+	KeyID string
+
+	// Label identifica a assinatura em Signature-Input/Signature. Default: "sig1".
+	Label string
+
+	// Components lista os componentes cobertos: derivados ("@method", "@target-uri",
+	// "@authority", "@scheme", "@path", "@query", "@request-target") ou nomes de headers.
+	// Default: DefaultComponents.
+	Components []string
+
+	// DigestAlgorithm é usado para gerar Content-Digest quando o header é assinado
+	// e ainda não existe: "sha-256" (default) ou "sha-512".
+	DigestAlgorithm string
+
+	// Expires define o parâmetro expires relativo ao created. Zero omite.
+	Expires time.Duration
+	// Nonce adiciona um nonce aleatório em cada assinatura.
+	Nonce bool
+	// Tag define o parâmetro tag (ex: perfil exigido pelo parceiro).
+	Tag string
+	// IncludeAlg envia o parâmetro alg com o algoritmo da Key.
+	IncludeAlg bool
+
+	// Now existe pra testes; se nil usa time.Now.
+	Now func() time.Time
+}
+
+// Signer adiciona os headers Signature-Input e Signature nas requests.
+type Signer struct {
+	cfg Config
+}
+
+// NewSigner cria um novo Signer.
+func NewSigner(cfg Config) (*Signer, error) {
+	if cfg.Key == nil {
+		return nil, errors.New("httpsig: key is required")
+	}
+	if cfg.Label == "" {
+		cfg.Label = "sig1"
+	}
+	if len(cfg.Components) == 0 {
+		cfg.Components = DefaultComponents
+	}
+	if cfg.DigestAlgorithm == "" {
+		cfg.DigestAlgorithm = "sha-256"
+	}
+	if cfg.DigestAlgorithm != "sha-256" && cfg.DigestAlgorithm != "sha-512" {
+		return nil, errors.New("httpsig: unsupported digest algorithm " + cfg.DigestAlgorithm)
+	}
+	if cfg.Now == nil {
+		cfg.Now = time.Now
+	}
+	return &Signer{cfg: cfg}, nil
+}
+
+// Apply assina a request. Deve rodar depois que headers e body estão definidos.
+func (s *Signer) Apply(req *http.Request) error {
+	if s == nil || req == nil {
+		return nil
+	}
+
+	for _, c := range s.cfg.Components {
+		if strings.EqualFold(c, "content-digest") && req.Header.Get("Content-Digest") == "" {
+			body, err := request.BodyBytes(req)
+			if err != nil {
+				return err
+			}
+			req.Header.Set("Content-Digest", ContentDigest(s.cfg.DigestAlgorithm, body))
+		}
+	}
+
+	params, err := s.signatureParams()
+	if err != nil {
+		return err
+	}
+	base, err := SignatureBase(req, s.cfg.Components, params)
+	if err != nil {
+		return err
+	}
+	sig, err := s.cfg.Key.Sign([]byte(base))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Signature-Input", s.cfg.Label+"="+params)
+	req.Header.Set("Signature", s.cfg.Label+"=:"+base64.StdEncoding.EncodeToString(sig)+":")
+	return nil
+}
+
+// signatureParams monta o valor de @signature-params (lista de componentes e parâmetros).
+func (s *Signer) signatureParams() (string, error) {
+	var b strings.Builder
+	b.WriteByte('(')
+	for i, c := range s.cfg.Components {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(strconv.Quote(strings.ToLower(c)))
+	}
+	b.WriteByte(')')
+
+	created := s.cfg.Now().Unix()
+	b.WriteString(";created=" + strconv.FormatInt(created, 10))
+	if s.cfg.Expires > 0 {
+		b.WriteString(";expires=" + strconv.FormatInt(created+int64(s.cfg.Expires/time.Second), 10))
+	}
+	if s.cfg.Nonce {
+		n := make([]byte, 16)
+		if _, err := rand.Read(n); err != nil {
+			return "", err
+		}
+		b.WriteString(`;nonce="` + hex.EncodeToString(n) + `"`)
+	}
+	if s.cfg.KeyID != "" {
+		b.WriteString(";keyid=" + strconv.Quote(s.cfg.KeyID))
+	}
+	if s.cfg.IncludeAlg {
+		b.WriteString(`;alg="` + s.cfg.Key.Algorithm() + `"`)
+	}
+	if s.cfg.Tag != "" {
+		b.WriteString(";tag=" + strconv.Quote(s.cfg.Tag))
+	}
+	return b.String(), nil
+}
+
+// SignatureBase monta a signature base do RFC 9421 §2.5 para os componentes informados.
+func SignatureBase(req *http.Request, components []string, params string) (string, error) {
+	var b strings.Builder
+	for _, c := range components {
+		name := strings.ToLower(c)
+		value, err := componentValue(req, name)
+		if err != nil {
+			return "", err
+		}
+		b.WriteString(strconv.Quote(name) + ": " + value + "\n")
+	}
+	b.WriteString(`"@signature-params": ` + params)
+	return b.String(), nil
+}
+
+func componentValue(req *http.Request, name string) (string, error) {
+	switch name {
+	case "@method":
+		return req.Method, nil
+	case "@target-uri":
+		return req.URL.String(), nil
+	case "@authority":
+		host := req.Host
+		if host == "" {
+			host = req.URL.Host
+		}
+		return strings.ToLower(host), nil
+	case "@scheme":
+		return strings.ToLower(req.URL.Scheme), nil
+	case "@path":
+		return req.URL.EscapedPath(), nil
+	case "@query":
+		return "?" + req.URL.RawQuery, nil
+	case "@request-target":
+		return req.URL.RequestURI(), nil
+	}
+	if strings.HasPrefix(name, "@") {
+		return "", errors.New("httpsig: unsupported derived component " + name)
+	}
+
+	// Host e Content-Length ficam em campos da http.Request, não no Header.
+	values := req.Header.Values(name)
+	if len(values) == 0 {
+		switch {
+		case name == "host" && req.Host != "":
+			values = []string{req.Host}
+		case name == "content-length" && req.ContentLength > 0:
+			values = []string{strconv.FormatInt(req.ContentLength, 10)}
+		}
+	}
+	if len(values) == 0 {
+		return "", errors.New("httpsig: header " + name + " not present in request")
+	}
+	trimmed := make([]string, len(values))
+	for i, v := range values {
+		trimmed[i] = strings.TrimSpace(v)
+	}
+	return strings.Join(trimmed, ", "), nil
+}
+
+// ContentDigest calcula o valor do header Content-Digest (RFC 9530) para o body.
+func ContentDigest(algorithm string, body []byte) string {
+	if algorithm == "sha-512" {
+		sum := sha512.Sum512(body)
+		return "sha-512=:" + base64.StdEncoding.EncodeToString(sum[:]) + ":"
+	}
+	sum := sha256.Sum256(body)
+	return "sha-256=:" + base64.StdEncoding.EncodeToString(sum[:]) + ":"
+}
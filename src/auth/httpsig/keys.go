@@ -0,0 +1,110 @@
+package httpsig
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+)
+
+// Key assina a signature base. Algorithm retorna o identificador do registro
+// de algoritmos do RFC 9421 (usado no parâmetro alg).
+type Key interface {
+	Algorithm() string
+	Sign(base []byte) ([]byte, error)
+}
+
+type hmacKey struct {
+	secret []byte
+}
+
+// HMACSHA256 cria uma chave hmac-sha256 a partir de um segredo compartilhado.
+func HMACSHA256(secret []byte) Key {
+	return hmacKey{secret: secret}
+}
+
+func (k hmacKey) Algorithm() string { return "hmac-sha256" }
+
+func (k hmacKey) Sign(base []byte) ([]byte, error) {
+	h := hmac.New(sha256.New, k.secret)
+	h.Write(base)
+	return h.Sum(nil), nil
+}
+
+type ed25519Key struct {
+	priv ed25519.PrivateKey
+}
+
+// Ed25519 cria uma chave ed25519.
+func Ed25519(priv ed25519.PrivateKey) Key {
+	return ed25519Key{priv: priv}
+}
+
+func (k ed25519Key) Algorithm() string { return "ed25519" }
+
+func (k ed25519Key) Sign(base []byte) ([]byte, error) {
+	return ed25519.Sign(k.priv, base), nil
+}
+
+type ecdsaKey struct {
+	priv *ecdsa.PrivateKey
+}
+
+// ECDSA cria uma chave ecdsa-p256-sha256 ou ecdsa-p384-sha384, conforme a curva.
+func ECDSA(priv *ecdsa.PrivateKey) (Key, error) {
+	switch priv.Curve {
+	case elliptic.P256(), elliptic.P384():
+		return ecdsaKey{priv: priv}, nil
+	}
+	return nil, errors.New("httpsig: unsupported ecdsa curve")
+}
+
+func (k ecdsaKey) Algorithm() string {
+	if k.priv.Curve == elliptic.P384() {
+		return "ecdsa-p384-sha384"
+	}
+	return "ecdsa-p256-sha256"
+}
+
+// Sign retorna r||s em tamanho fixo, como exige o RFC 9421 (não DER).
+func (k ecdsaKey) Sign(base []byte) ([]byte, error) {
+	var digest []byte
+	if k.priv.Curve == elliptic.P384() {
+		sum := sha512.Sum384(base)
+		digest = sum[:]
+	} else {
+		sum := sha256.Sum256(base)
+		digest = sum[:]
+	}
+	r, s, err := ecdsa.Sign(rand.Reader, k.priv, digest)
+	if err != nil {
+		return nil, err
+	}
+	size := (k.priv.Curve.Params().BitSize + 7) / 8
+	sig := make([]byte, 2*size)
+	r.FillBytes(sig[:size])
+	s.FillBytes(sig[size:])
+	return sig, nil
+}
+
+type rsaPSSKey struct {
+	priv *rsa.PrivateKey
+}
+
+// RSAPSS cria uma chave rsa-pss-sha512.
+func RSAPSS(priv *rsa.PrivateKey) Key {
+	return rsaPSSKey{priv: priv}
+}
+
+func (k rsaPSSKey) Algorithm() string { return "rsa-pss-sha512" }
+
+func (k rsaPSSKey) Sign(base []byte) ([]byte, error) {
+	sum := sha512.Sum512(base)
+	return rsa.SignPSS(rand.Reader, k.priv, crypto.SHA512, sum[:], &rsa.PSSOptions{SaltLength: 64})
+}
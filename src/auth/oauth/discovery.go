@@ -0,0 +1,138 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Metadata contém os campos relevantes do documento de discovery do servidor de autorização
+// (OpenID Connect Discovery ou RFC 8414).
+type Metadata struct {
+	Issuer                            string   `json:"issuer"`
+	TokenEndpoint                     string   `json:"token_endpoint"`
+	TokenEndpointAuthMethodsSupported []string `json:"token_endpoint_auth_methods_supported"`
+	GrantTypesSupported               []string `json:"grant_types_supported"`
+}
+
+// supportsClientSecretBasic indica se o servidor aceita credenciais via Basic Auth.
+// Pela spec, a ausência da lista implica client_secret_basic.
+func (m *Metadata) supportsClientSecretBasic() bool {
+	return len(m.TokenEndpointAuthMethodsSupported) == 0 ||
+		slices.Contains(m.TokenEndpointAuthMethodsSupported, "client_secret_basic")
+}
+
+func (m *Metadata) supportsClientSecretPost() bool {
+	return slices.Contains(m.TokenEndpointAuthMethodsSupported, "client_secret_post")
+}
+
+// supportsClientCredentials indica se o grant client_credentials é aceito.
+// A ausência da lista não restringe nada.
+func (m *Metadata) supportsClientCredentials() bool {
+	return len(m.GrantTypesSupported) == 0 || slices.Contains(m.GrantTypesSupported, "client_credentials")
+}
+
+type metadataCache struct {
+	mu        sync.Mutex
+	md        *Metadata
+	fetchedAt time.Time
+}
+
+// metadata retorna o documento de discovery do Issuer, usando o cache enquanto estiver dentro do TTL.
+func (s *TokenSource[T]) metadata(ctx context.Context) (*Metadata, error) {
+	s.meta.mu.Lock()
+	defer s.meta.mu.Unlock()
+
+	if s.meta.md != nil && s.now().Before(s.meta.fetchedAt.Add(s.cfg.MetadataTTL)) {
+		return s.meta.md, nil
+	}
+
+	md, err := s.discover(ctx)
+	if err != nil {
+		if s.meta.md != nil {
+			// Mantém o documento anterior se o servidor de discovery estiver indisponível.
+			return s.meta.md, nil
+		}
+		return nil, err
+	}
+	s.meta.md = md
+	s.meta.fetchedAt = s.now()
+	return md, nil
+}
+
+func (s *TokenSource[T]) discover(ctx context.Context) (*Metadata, error) {
+	urls, err := metadataURLs(s.cfg.Issuer)
+	if err != nil {
+		return nil, err
+	}
+
+	var lastErr error
+	for _, u := range urls {
+		md, err := s.fetchMetadata(ctx, u)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if md.Issuer != "" && strings.TrimSuffix(md.Issuer, "/") != strings.TrimSuffix(s.cfg.Issuer, "/") {
+			return nil, errors.New("oauth: discovery issuer mismatch: " + md.Issuer)
+		}
+		if md.TokenEndpoint == "" {
+			lastErr = errors.New("oauth: discovery document without token_endpoint")
+			continue
+		}
+		return md, nil
+	}
+	return nil, lastErr
+}
+
+func (s *TokenSource[T]) fetchMetadata(ctx context.Context, rawURL string) (*Metadata, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.New("oauth: discovery endpoint returned error: " + resp.Status)
+	}
+
+	var md Metadata
+	if err := json.NewDecoder(resp.Body).Decode(&md); err != nil {
+		return nil, err
+	}
+	return &md, nil
+}
+
+// metadataURLs monta os endereços de discovery para o issuer, na ordem de tentativa:
+// OpenID Connect ({issuer}/.well-known/openid-configuration) e RFC 8414
+// (/.well-known/oauth-authorization-server{path}).
+func metadataURLs(issuer string) ([]string, error) {
+	u, err := url.Parse(issuer)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, errors.New("oauth: issuer must be an absolute URL")
+	}
+	path := strings.TrimSuffix(u.Path, "/")
+
+	oidc := *u
+	oidc.Path = path + "/.well-known/openid-configuration"
+
+	rfc8414 := *u
+	rfc8414.Path = "/.well-known/oauth-authorization-server" + path
+
+	return []string{oidc.String(), rfc8414.String()}, nil
+}
@@ -0,0 +1,101 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTokenSource_IssuerDiscovery(t *testing.T) {
+	t.Parallel()
+
+	var discoveryCalls atomic.Int64
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/.well-known/oauth-authorization-server/tenant-a":
+			discoveryCalls.Add(1)
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"issuer":                                srv.URL + "/tenant-a",
+				"token_endpoint":                        srv.URL + "/tenant-a/token",
+				"token_endpoint_auth_methods_supported": []string{"client_secret_post"},
+				"grant_types_supported":                 []string{"client_credentials"},
+			})
+		case "/tenant-a/token":
+			_ = r.ParseForm()
+			if _, _, ok := r.BasicAuth(); ok || r.PostForm.Get("client_secret") != "secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"access_token": "discovered",
+				"expires_in":   1,
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	var nowUnix atomic.Int64
+	nowUnix.Store(time.Unix(1000, 0).Unix())
+	now := func() time.Time { return time.Unix(nowUnix.Load(), 0) }
+
+	src := NewTokenSource(srv.Client(), nil, Config[DefaultTokenResponse]{
+		Issuer:        srv.URL + "/tenant-a",
+		ClientID:      "id",
+		ClientSecret:  "secret",
+		RefreshBefore: time.Millisecond,
+		MetadataTTL:   time.Minute,
+		Now:           now,
+	})
+
+	tok, err := src.Token(context.Background())
+	if err != nil {
+		t.Fatalf("Token() err=%v", err)
+	}
+	if tok != "discovered" {
+		t.Fatalf("expected discovered; got=%v", tok)
+	}
+
+	// Token expira, mas o documento de discovery ainda está no TTL.
+	nowUnix.Add(2)
+	if _, err := src.Token(context.Background()); err != nil {
+		t.Fatalf("Token() err=%v", err)
+	}
+	if discoveryCalls.Load() != 1 {
+		t.Fatalf("expected cached metadata; got %d discovery calls", discoveryCalls.Load())
+	}
+
+	nowUnix.Add(120)
+	if _, err := src.Token(context.Background()); err != nil {
+		t.Fatalf("Token() err=%v", err)
+	}
+	if discoveryCalls.Load() != 2 {
+		t.Fatalf("expected metadata refetch after TTL; got %d discovery calls", discoveryCalls.Load())
+	}
+}
+
+func TestMetadataURLs(t *testing.T) {
+	urls, err := metadataURLs("https://auth.example.com/realms/x/")
+	if err != nil {
+		t.Fatalf("metadataURLs err=%v", err)
+	}
+	want := []string{
+		"https://auth.example.com/realms/x/.well-known/openid-configuration",
+		"https://auth.example.com/.well-known/oauth-authorization-server/realms/x",
+	}
+	for i := range want {
+		if urls[i] != want[i] {
+			t.Errorf("urls[%d] = %q, want %q", i, urls[i], want[i])
+		}
+	}
+
+	if _, err := metadataURLs("auth.example.com"); err == nil {
+		t.Error("expected error for relative issuer")
+	}
+}
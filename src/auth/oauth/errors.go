@@ -0,0 +1,91 @@
+package oauth
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+)
+
+// Erros do RFC 6749 §5.2, para uso com errors.Is em um *Error.
+var (
+	ErrInvalidRequest         = errors.New("oauth: invalid_request")
+	ErrInvalidClient          = errors.New("oauth: invalid_client")
+	ErrInvalidGrant           = errors.New("oauth: invalid_grant")
+	ErrUnauthorizedClient     = errors.New("oauth: unauthorized_client")
+	ErrUnsupportedGrantType   = errors.New("oauth: unsupported_grant_type")
+	ErrInvalidScope           = errors.New("oauth: invalid_scope")
+	ErrServerError            = errors.New("oauth: server_error")
+	ErrTemporarilyUnavailable = errors.New("oauth: temporarily_unavailable")
+)
+
+var errorCodes = map[string]error{
+	"invalid_request":         ErrInvalidRequest,
+	"invalid_client":          ErrInvalidClient,
+	"invalid_grant":           ErrInvalidGrant,
+	"unauthorized_client":     ErrUnauthorizedClient,
+	"unsupported_grant_type":  ErrUnsupportedGrantType,
+	"invalid_scope":           ErrInvalidScope,
+	"server_error":            ErrServerError,
+	"temporarily_unavailable": ErrTemporarilyUnavailable,
+}
+
+// Error representa uma resposta de erro do token endpoint.
+type Error struct {
+	StatusCode  int
+	Code        string
+	Description string
+	URI         string
+
+	// Body guarda o corpo bruto quando a resposta não segue o formato do RFC 6749.
+	Body string
+}
+
+func (e *Error) Error() string {
+	msg := "oauth: token endpoint returned error: " + strconv.Itoa(e.StatusCode)
+	if e.Code != "" {
+		msg += " " + e.Code
+		if e.Description != "" {
+			msg += ": " + e.Description
+		}
+		return msg
+	}
+	if e.Body != "" {
+		msg += " body=" + e.Body
+	}
+	return msg
+}
+
+// Is permite comparar com os erros sentinela (ex: errors.Is(err, oauth.ErrInvalidClient)).
+func (e *Error) Is(target error) bool {
+	sentinel, ok := errorCodes[e.Code]
+	return ok && sentinel == target
+}
+
+// Retryable indica se a falha é transitória (indisponibilidade do servidor de autorização)
+// e não um problema de credenciais ou configuração.
+func (e *Error) Retryable() bool {
+	switch e.Code {
+	case "server_error", "temporarily_unavailable":
+		return true
+	}
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= 500
+}
+
+// parseError monta um *Error a partir da resposta do token endpoint.
+func parseError(statusCode int, body []byte) *Error {
+	e := &Error{StatusCode: statusCode}
+	var payload struct {
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+		ErrorURI         string `json:"error_uri"`
+	}
+	if json.Unmarshal(body, &payload) == nil && payload.Error != "" {
+		e.Code = payload.Error
+		e.Description = payload.ErrorDescription
+		e.URI = payload.ErrorURI
+		return e
+	}
+	e.Body = string(body)
+	return e
+}
@@ -0,0 +1,69 @@
+package oauth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTokenSource_StructuredError(t *testing.T) {
+	t.Parallel()
+
+	tokenSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"error":"invalid_client","error_description":"bad secret","error_uri":"https://docs/errors"}`))
+	}))
+	t.Cleanup(tokenSrv.Close)
+
+	src := NewTokenSource(http.DefaultClient, nil, Config[DefaultTokenResponse]{
+		TokenURL:     tokenSrv.URL,
+		ClientID:     "id",
+		ClientSecret: "wrong",
+	})
+
+	_, err := src.Token(context.Background())
+	if !errors.Is(err, ErrInvalidClient) {
+		t.Fatalf("expected ErrInvalidClient; got=%v", err)
+	}
+	var oe *Error
+	if !errors.As(err, &oe) {
+		t.Fatalf("expected *Error; got=%T", err)
+	}
+	if oe.StatusCode != http.StatusUnauthorized || oe.Description != "bad secret" || oe.URI != "https://docs/errors" {
+		t.Fatalf("unexpected error fields: %+v", oe)
+	}
+	if oe.Retryable() {
+		t.Fatal("invalid_client should not be retryable")
+	}
+}
+
+func TestParseError(t *testing.T) {
+	tests := []struct {
+		status    int
+		body      string
+		sentinel  error
+		retryable bool
+	}{
+		{http.StatusBadRequest, `{"error":"invalid_grant"}`, ErrInvalidGrant, false},
+		{http.StatusBadRequest, `{"error":"invalid_scope"}`, ErrInvalidScope, false},
+		{http.StatusServiceUnavailable, `{"error":"temporarily_unavailable"}`, ErrTemporarilyUnavailable, true},
+		{http.StatusBadGateway, `<html>bad gateway</html>`, nil, true},
+		{http.StatusTooManyRequests, ``, nil, true},
+	}
+
+	for _, tt := range tests {
+		e := parseError(tt.status, []byte(tt.body))
+		if tt.sentinel != nil && !errors.Is(e, tt.sentinel) {
+			t.Errorf("parseError(%d, %q) should match %v", tt.status, tt.body, tt.sentinel)
+		}
+		if e.Retryable() != tt.retryable {
+			t.Errorf("parseError(%d, %q).Retryable() = %v, want %v", tt.status, tt.body, e.Retryable(), tt.retryable)
+		}
+		if e.Error() == "" {
+			t.Errorf("parseError(%d, %q) has empty message", tt.status, tt.body)
+		}
+	}
+}
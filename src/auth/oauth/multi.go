@@ -0,0 +1,229 @@
+package oauth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// ErrNoTenant indica que não foi possível extrair o tenant da request.
+var ErrNoTenant = errors.New("oauth: tenant not found in request")
+
+type tenantKey struct{}
+
+// WithTenant associa a chave do tenant ao context usado em Request.Do.
+func WithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenant)
+}
+
+// TenantFromContext retorna a chave do tenant definida via WithTenant.
+func TenantFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	tenant, _ := ctx.Value(tenantKey{}).(string)
+	return tenant
+}
+
+// TenantFunc extrai a chave do tenant de uma request.
+type TenantFunc func(req *http.Request) string
+
+// TenantFromHeader extrai o tenant de um header, usando o context como fallback.
+func TenantFromHeader(name string) TenantFunc {
+	return func(req *http.Request) string {
+		if tenant := req.Header.Get(name); tenant != "" {
+			return tenant
+		}
+		return TenantFromContext(req.Context())
+	}
+}
+
+// MultiConfig contém as configurações do MultiTokenSource.
+type MultiConfig[T TokenResponse] struct {
+	// Config retorna a configuração OAuth2 do tenant. Obrigatório.
+	Config func(ctx context.Context, tenant string) (Config[T], error)
+
+	// Tenant extrai o tenant da request. Default: TenantFromContext.
+	Tenant TenantFunc
+
+	// MaxTenants limita quantos TokenSource ficam em memória; o menos usado é descartado.
+	// Default: 100.
+	MaxTenants int
+
+	// IdleTTL descarta TokenSource sem uso há mais que esse tempo. Zero desabilita.
+	IdleTTL time.Duration
+
+	// Now existe pra testes; se nil usa time.Now.
+	Now func() time.Time
+}
+
+type tenantSource[T TokenResponse] struct {
+	src      *TokenSource[T]
+	lastUsed time.Time
+}
+
+// MultiTokenSource seleciona um TokenSource por tenant, criando-os sob demanda.
+type MultiTokenSource[T TokenResponse] struct {
+	httpClient *http.Client
+	logger     *zap.Logger
+	cfg        MultiConfig[T]
+
+	mu      sync.Mutex
+	sources map[string]*tenantSource[T]
+}
+
+// NewMultiTokenSource cria um novo MultiTokenSource.
+func NewMultiTokenSource[T TokenResponse](httpClient *http.Client, logger *zap.Logger, cfg MultiConfig[T]) *MultiTokenSource[T] {
+	if cfg.Tenant == nil {
+		cfg.Tenant = func(req *http.Request) string { return TenantFromContext(req.Context()) }
+	}
+	if cfg.MaxTenants <= 0 {
+		cfg.MaxTenants = 100
+	}
+	if cfg.Now == nil {
+		cfg.Now = time.Now
+	}
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	return &MultiTokenSource[T]{
+		httpClient: httpClient,
+		logger:     logger,
+		cfg:        cfg,
+		sources:    make(map[string]*tenantSource[T]),
+	}
+}
+
+// Apply aplica o token do tenant da request no header Authorization.
+func (m *MultiTokenSource[T]) Apply(req *http.Request) error {
+	if m == nil || req == nil {
+		return nil
+	}
+	src, err := m.sourceFor(req)
+	if err != nil {
+		return err
+	}
+	return src.Apply(req)
+}
+
+// HandleUnauthorized invalida o token do tenant da request (ver TokenSource.HandleUnauthorized).
+func (m *MultiTokenSource[T]) HandleUnauthorized(req *http.Request, resp *http.Response) (bool, error) {
+	if m == nil || req == nil {
+		return false, nil
+	}
+	src, err := m.sourceFor(req)
+	if err != nil {
+		return false, nil
+	}
+	return src.HandleUnauthorized(req, resp)
+}
+
+func (m *MultiTokenSource[T]) sourceFor(req *http.Request) (*TokenSource[T], error) {
+	tenant := m.cfg.Tenant(req)
+	if tenant == "" {
+		return nil, ErrNoTenant
+	}
+	return m.Source(req.Context(), tenant)
+}
+
+// lookup retorna o TokenSource já criado do tenant, removendo antes os ociosos.
+func (m *MultiTokenSource[T]) lookup(tenant string) (*TokenSource[T], bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.cfg.Now()
+	m.evictIdleLocked(now)
+	ts, ok := m.sources[tenant]
+	if !ok {
+		return nil, false
+	}
+	ts.lastUsed = now
+	return ts.src, true
+}
+
+// Source retorna o TokenSource do tenant, criando-o na primeira chamada.
+func (m *MultiTokenSource[T]) Source(ctx context.Context, tenant string) (*TokenSource[T], error) {
+	if m.cfg.Config == nil {
+		return nil, errors.New("oauth: MultiConfig.Config is required")
+	}
+
+	if src, ok := m.lookup(tenant); ok {
+		return src, nil
+	}
+
+	// Config pode fazer I/O (ex: secret store); resolve fora do lock para não bloquear
+	// os demais tenants.
+	cfg, err := m.cfg.Config(ctx, tenant)
+	if err != nil {
+		return nil, err
+	}
+	src := NewTokenSource(m.httpClient, m.logger.With(zap.String("tenant", tenant)), cfg)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.cfg.Now()
+	if ts, ok := m.sources[tenant]; ok {
+		// Outra chamada criou o source enquanto a config era resolvida.
+		ts.lastUsed = now
+		return ts.src, nil
+	}
+	if cfg.BackgroundRefresh {
+		src.Start(context.Background())
+	}
+	if len(m.sources) >= m.cfg.MaxTenants {
+		m.evictOldestLocked()
+	}
+	m.sources[tenant] = &tenantSource[T]{src: src, lastUsed: now}
+	return src, nil
+}
+
+// Stop encerra a renovação em background de todos os tenants e limpa o cache.
+func (m *MultiTokenSource[T]) Stop() {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	sources := m.sources
+	m.sources = make(map[string]*tenantSource[T])
+	m.mu.Unlock()
+
+	for _, ts := range sources {
+		ts.src.Stop()
+	}
+}
+
+func (m *MultiTokenSource[T]) evictIdleLocked(now time.Time) {
+	if m.cfg.IdleTTL <= 0 {
+		return
+	}
+	for tenant, ts := range m.sources {
+		if now.Sub(ts.lastUsed) > m.cfg.IdleTTL {
+			m.evictLocked(tenant)
+		}
+	}
+}
+
+func (m *MultiTokenSource[T]) evictOldestLocked() {
+	var oldest string
+	var oldestAt time.Time
+	for tenant, ts := range m.sources {
+		if oldest == "" || ts.lastUsed.Before(oldestAt) {
+			oldest, oldestAt = tenant, ts.lastUsed
+		}
+	}
+	if oldest != "" {
+		m.evictLocked(oldest)
+	}
+}
+
+func (m *MultiTokenSource[T]) evictLocked(tenant string) {
+	ts := m.sources[tenant]
+	delete(m.sources, tenant)
+	// Stop aguarda a goroutine de renovação; roda fora do lock para não bloquear outras requests.
+	go ts.src.Stop()
+}
@@ -0,0 +1,132 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMultiTokenSource_PerTenant(t *testing.T) {
+	t.Parallel()
+
+	var tokenCalls atomic.Int64
+	tokenSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tokenCalls.Add(1)
+		id, _, _ := r.BasicAuth()
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token": "token-" + id,
+			"expires_in":   3600,
+		})
+	}))
+	t.Cleanup(tokenSrv.Close)
+
+	var nowUnix atomic.Int64
+	nowUnix.Store(time.Unix(1000, 0).Unix())
+	now := func() time.Time { return time.Unix(nowUnix.Load(), 0) }
+
+	m := NewMultiTokenSource(http.DefaultClient, nil, MultiConfig[DefaultTokenResponse]{
+		Config: func(ctx context.Context, tenant string) (Config[DefaultTokenResponse], error) {
+			return Config[DefaultTokenResponse]{
+				TokenURL:     tokenSrv.URL,
+				ClientID:     tenant,
+				ClientSecret: "secret",
+			}, nil
+		},
+		Tenant:     TenantFromHeader("X-Tenant"),
+		MaxTenants: 2,
+		IdleTTL:    time.Minute,
+		Now:        now,
+	})
+	t.Cleanup(m.Stop)
+
+	apply := func(ctx context.Context, header string) string {
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "http://example.com", nil)
+		if header != "" {
+			req.Header.Set("X-Tenant", header)
+		}
+		if err := m.Apply(req); err != nil {
+			t.Fatalf("Apply() err=%v", err)
+		}
+		return req.Header.Get("Authorization")
+	}
+
+	if got := apply(context.Background(), "a"); got != "Bearer token-a" {
+		t.Fatalf("expected token-a; got=%v", got)
+	}
+	if got := apply(WithTenant(context.Background(), "b"), ""); got != "Bearer token-b" {
+		t.Fatalf("expected token-b; got=%v", got)
+	}
+	apply(context.Background(), "a")
+	if tokenCalls.Load() != 2 {
+		t.Fatalf("expected cached per-tenant sources; got %d token calls", tokenCalls.Load())
+	}
+
+	// Terceiro tenant excede MaxTenants e descarta o menos usado.
+	nowUnix.Add(1)
+	apply(context.Background(), "c")
+	if len(m.sources) != 2 {
+		t.Fatalf("expected 2 cached tenants; got=%d", len(m.sources))
+	}
+
+	// Sources ociosos além do IdleTTL são descartados.
+	nowUnix.Add(120)
+	apply(context.Background(), "a")
+	if len(m.sources) != 1 {
+		t.Fatalf("expected idle tenants evicted; got=%d", len(m.sources))
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, "http://example.com", nil)
+	if err := m.Apply(req); !errors.Is(err, ErrNoTenant) {
+		t.Fatalf("expected ErrNoTenant; got=%v", err)
+	}
+}
+
+func TestMultiTokenSource_SlowConfigDoesNotBlockOtherTenants(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	slowStarted := make(chan struct{})
+	m := NewMultiTokenSource(http.DefaultClient, nil, MultiConfig[DefaultTokenResponse]{
+		Config: func(ctx context.Context, tenant string) (Config[DefaultTokenResponse], error) {
+			if tenant == "slow" {
+				close(slowStarted)
+				<-release
+			}
+			return Config[DefaultTokenResponse]{TokenURL: "http://127.0.0.1:1", ClientID: tenant}, nil
+		},
+	})
+	t.Cleanup(m.Stop)
+
+	slowDone := make(chan *TokenSource[DefaultTokenResponse])
+	go func() {
+		src, _ := m.Source(context.Background(), "slow")
+		slowDone <- src
+	}()
+	<-slowStarted
+
+	fast := make(chan error, 1)
+	go func() {
+		_, err := m.Source(context.Background(), "fast")
+		fast <- err
+	}()
+	select {
+	case err := <-fast:
+		if err != nil {
+			t.Fatalf("Source(fast) err=%v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("slow tenant config blocked other tenants")
+	}
+
+	close(release)
+	src := <-slowDone
+	again, err := m.Source(context.Background(), "slow")
+	if err != nil || again != src {
+		t.Fatalf("expected cached source for slow tenant; got %p want %p err=%v", again, src, err)
+	}
+}
@@ -30,6 +30,15 @@ type Config[T TokenResponse] struct {
 	ClientSecret string
 	Scopes       []string
 
+	// Issuer permite omitir TokenURL: o token endpoint e os métodos de autenticação
+	// suportados são descobertos via /.well-known/openid-configuration ou RFC 8414.
+	// Ignorado quando TokenURL é informado.
+	Issuer string
+
+	// MetadataTTL controla por quanto tempo o documento de discovery fica em cache.
+	// Default: 1h.
+	MetadataTTL time.Duration
+
 	// ExtraParams injeta parâmetros adicionais no form (ex: audience, resource, etc).
 	ExtraParams map[string]string
 
@@ -40,6 +49,19 @@ type Config[T TokenResponse] struct {
 	// Default: 30s.
 	RefreshBefore time.Duration
 
+	// BackgroundRefresh renova o token numa goroutine (ver TokenSource.Start) antes de
+	// entrar na janela de RefreshBefore, para que as requests não esperem o token endpoint.
+	// Usado pelas opções do client e pelo MultiTokenSource; com NewTokenSource direto,
+	// chame Start e Stop.
+	BackgroundRefresh bool
+
+	// RefreshBackoff é o intervalo inicial entre tentativas quando a renovação em background falha.
+	// Dobra a cada falha até RefreshMaxBackoff. Default: 1s.
+	RefreshBackoff time.Duration
+
+	// RefreshMaxBackoff limita o backoff da renovação em background. Default: 1m.
+	RefreshMaxBackoff time.Duration
+
 	// Now existe pra testes; se nil usa time.Now.
 	Now func() time.Time
 }
@@ -52,9 +74,15 @@ type TokenSource[T TokenResponse] struct {
 
 	mu            sync.Mutex
 	token         string
+	issuedAt      time.Time
 	expiresAt     time.Time
 	refreshBefore time.Duration
 	now           func() time.Time
+
+	meta metadataCache
+
+	stop context.CancelFunc
+	done chan struct{}
 }
 
 // NewTokenSource cria um novo TokenSource.
@@ -65,6 +93,15 @@ func NewTokenSource[T TokenResponse](httpClient *http.Client, logger *zap.Logger
 	if cfg.Now == nil {
 		cfg.Now = time.Now
 	}
+	if cfg.MetadataTTL <= 0 {
+		cfg.MetadataTTL = time.Hour
+	}
+	if cfg.RefreshBackoff <= 0 {
+		cfg.RefreshBackoff = time.Second
+	}
+	if cfg.RefreshMaxBackoff < cfg.RefreshBackoff {
+		cfg.RefreshMaxBackoff = max(time.Minute, cfg.RefreshBackoff)
+	}
 	if logger == nil {
 		logger = zap.NewNop()
 	}
@@ -117,12 +154,19 @@ func (s *TokenSource[T]) Token(ctx context.Context) (string, error) {
 		return s.token, nil
 	}
 
+	// Com a renovação em background ativa, um token ainda não expirado é servido
+	// enquanto a goroutine tenta renovar.
+	if s.stop != nil && s.token != "" && s.now().Before(s.expiresAt) {
+		return s.token, nil
+	}
+
 	if s.cfg.Cache != nil {
 		key := s.cacheKey()
 		if cached, err := s.cfg.Cache.Get(ctx, key); err == nil && cached != "" {
 			var ct oauthCachedToken
 			if json.Unmarshal([]byte(cached), &ct) == nil && ct.AccessToken != "" {
 				s.token = ct.AccessToken
+				s.issuedAt = s.now()
 				if ct.ExpiresIn > 0 {
 					s.expiresAt = s.now().Add(time.Duration(ct.ExpiresIn) * time.Second)
 				} else {
@@ -139,15 +183,21 @@ func (s *TokenSource[T]) Token(ctx context.Context) (string, error) {
 		return "", err
 	}
 
+	s.storeLocked(ctx, tok, expiresIn)
+	return s.token, nil
+}
+
+// storeLocked guarda o token em memória e no cache externo. Deve ser chamada com s.mu travado.
+func (s *TokenSource[T]) storeLocked(ctx context.Context, tok string, expiresIn int64) {
 	s.token = tok
+	s.issuedAt = s.now()
 	var ttl time.Duration
 	if expiresIn > 0 {
 		ttl = time.Duration(expiresIn) * time.Second
-		s.expiresAt = s.now().Add(ttl)
 	} else {
 		ttl = s.refreshBefore
-		s.expiresAt = s.now().Add(ttl)
 	}
+	s.expiresAt = s.issuedAt.Add(ttl)
 
 	if s.cfg.Cache != nil {
 		key := s.cacheKey()
@@ -159,8 +209,184 @@ func (s *TokenSource[T]) Token(ctx context.Context) (string, error) {
 			_ = s.cfg.Cache.Set(ctx, key, string(b), ttl)
 		}
 	}
+}
 
-	return s.token, nil
+// Start inicia a renovação proativa do token em background.
+// O token é renovado antes de entrar na janela de RefreshBefore; em caso de falha,
+// o token anterior continua sendo servido enquanto for válido e novas tentativas
+// seguem com backoff exponencial. Chamadas repetidas são ignoradas.
+func (s *TokenSource[T]) Start(ctx context.Context) {
+	if s == nil {
+		return
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	s.mu.Lock()
+	if s.stop != nil {
+		s.mu.Unlock()
+		return
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	s.stop = cancel
+	s.done = done
+	s.mu.Unlock()
+
+	go s.refreshLoop(ctx, done)
+}
+
+// Stop encerra a renovação em background e aguarda a goroutine terminar.
+func (s *TokenSource[T]) Stop() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	cancel, done := s.stop, s.done
+	s.stop, s.done = nil, nil
+	s.mu.Unlock()
+
+	if cancel == nil {
+		return
+	}
+	cancel()
+	<-done
+}
+
+func (s *TokenSource[T]) refreshLoop(ctx context.Context, done chan struct{}) {
+	defer close(done)
+
+	backoff := s.cfg.RefreshBackoff
+	failing := false
+	for {
+		delay := s.nextRefreshDelay()
+		if failing && delay < backoff {
+			delay = backoff
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		if err := s.refresh(ctx); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			s.logger.Warn("goxios oauth: background token refresh failed",
+				zap.Duration("retry_in", backoff),
+				zap.Error(err),
+			)
+			if failing {
+				backoff = min(backoff*2, s.cfg.RefreshMaxBackoff)
+			}
+			failing = true
+			continue
+		}
+		failing = false
+		backoff = s.cfg.RefreshBackoff
+	}
+}
+
+// nextRefreshDelay calcula quando a goroutine deve renovar: um RefreshBefore antes da
+// janela de renovação síncrona, mas nunca antes da metade da vida útil do token.
+func (s *TokenSource[T]) nextRefreshDelay() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token == "" {
+		return 0
+	}
+	at := s.expiresAt.Add(-2 * s.refreshBefore)
+	if half := s.issuedAt.Add(s.expiresAt.Sub(s.issuedAt) / 2); at.Before(half) {
+		at = half
+	}
+	return max(at.Sub(s.now()), 0)
+}
+
+// refresh busca um novo token sem segurar s.mu durante a chamada de rede.
+func (s *TokenSource[T]) refresh(ctx context.Context) error {
+	tok, expiresIn, err := s.fetchToken(ctx)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.storeLocked(ctx, tok, expiresIn)
+	return nil
+}
+
+// Invalidate descarta o token atual (memória e cache externo) para forçar uma nova busca.
+// Se rejected não for vazio, só invalida quando o token atual ainda é o rejeitado,
+// evitando descartar um token que outra goroutine/instância acabou de renovar.
+func (s *TokenSource[T]) Invalidate(ctx context.Context, rejected string) {
+	if s == nil {
+		return
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if rejected == "" || s.token == rejected {
+		s.token = ""
+		s.expiresAt = time.Time{}
+	}
+
+	if s.cfg.Cache == nil {
+		return
+	}
+	key := s.cacheKey()
+	if rejected != "" {
+		cached, err := s.cfg.Cache.Get(ctx, key)
+		if err != nil || cached == "" {
+			return
+		}
+		var ct oauthCachedToken
+		if json.Unmarshal([]byte(cached), &ct) != nil || ct.AccessToken != rejected {
+			return
+		}
+	}
+	if d, ok := s.cfg.Cache.(cache.TokenCacheDeleter); ok {
+		_ = d.Delete(ctx, key)
+		return
+	}
+	// Sem suporte a Delete: sobrescreve com valor vazio, que é ignorado na leitura.
+	_ = s.cfg.Cache.Set(ctx, key, "", time.Second)
+}
+
+// HandleUnauthorized trata respostas 401 ou com WWW-Authenticate: Bearer error="invalid_token".
+// Invalida o token usado na request e sinaliza que ela pode ser repetida com um novo token.
+func (s *TokenSource[T]) HandleUnauthorized(req *http.Request, resp *http.Response) (bool, error) {
+	if s == nil || req == nil || resp == nil {
+		return false, nil
+	}
+	if resp.StatusCode != http.StatusUnauthorized && !isInvalidTokenChallenge(resp.Header) {
+		return false, nil
+	}
+	rejected := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+	if rejected == "" {
+		return false, nil
+	}
+	s.logger.Debug("goxios oauth: token rejected, invalidating", zap.Int("status", resp.StatusCode))
+	s.Invalidate(req.Context(), rejected)
+	return true, nil
+}
+
+func isInvalidTokenChallenge(h http.Header) bool {
+	for _, v := range h.Values("WWW-Authenticate") {
+		lv := strings.ToLower(v)
+		if strings.HasPrefix(lv, "bearer") && strings.Contains(lv, `error="invalid_token"`) {
+			return true
+		}
+	}
+	return false
 }
 
 func (s *TokenSource[T]) shouldRefreshLocked() bool {
@@ -181,16 +407,37 @@ func (s *TokenSource[T]) fetchToken(ctx context.Context) (string, int64, error)
 	if len(s.cfg.Scopes) > 0 {
 		form.Set("scope", strings.Join(s.cfg.Scopes, " "))
 	}
+
+	tokenURL := s.cfg.TokenURL
+	basicAuth := true
+	if tokenURL == "" && s.cfg.Issuer != "" {
+		md, err := s.metadata(ctx)
+		if err != nil {
+			return "", 0, err
+		}
+		if !md.supportsClientCredentials() {
+			return "", 0, errors.New("oauth: issuer does not support client_credentials grant")
+		}
+		tokenURL = md.TokenEndpoint
+		basicAuth = md.supportsClientSecretBasic() || !md.supportsClientSecretPost()
+	}
+	if !basicAuth {
+		form.Set("client_id", s.cfg.ClientID)
+		form.Set("client_secret", s.cfg.ClientSecret)
+	}
+
 	for k, v := range s.cfg.ExtraParams {
 		form.Set(k, v)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.cfg.TokenURL, strings.NewReader(form.Encode()))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, strings.NewReader(form.Encode()))
 	if err != nil {
 		return "", 0, err
 	}
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-	req.SetBasicAuth(s.cfg.ClientID, s.cfg.ClientSecret)
+	if basicAuth {
+		req.SetBasicAuth(s.cfg.ClientID, s.cfg.ClientSecret)
+	}
 
 	resp, err := s.httpClient.Do(req)
 	if err != nil {
@@ -200,7 +447,7 @@ func (s *TokenSource[T]) fetchToken(ctx context.Context) (string, int64, error)
 
 	if resp.StatusCode >= 400 {
 		b, _ := io.ReadAll(resp.Body)
-		return "", 0, errors.New("oauth: token endpoint returned error: " + resp.Status + " body=" + string(b))
+		return "", 0, parseError(resp.StatusCode, b)
 	}
 
 	var tr T
@@ -183,3 +183,130 @@ func TestTokenSource_CustomResponse(t *testing.T) {
 	}
 }
 
+func TestTokenSource_HandleUnauthorizedInvalidatesToken(t *testing.T) {
+	t.Parallel()
+
+	cache := &memTokenCache{}
+
+	var tokenCalls atomic.Int64
+	tokenSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tokenCalls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token": fmt.Sprintf("t%d", tokenCalls.Load()),
+			"expires_in":   3600,
+		})
+	}))
+	t.Cleanup(tokenSrv.Close)
+
+	src := NewTokenSource(http.DefaultClient, nil, Config[DefaultTokenResponse]{
+		TokenURL:     tokenSrv.URL,
+		ClientID:     "id",
+		ClientSecret: "secret",
+		Cache:        cache,
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "http://example.com", nil)
+	if err := src.Apply(req); err != nil {
+		t.Fatalf("Apply() err=%v", err)
+	}
+	if got := req.Header.Get("Authorization"); got != "Bearer t1" {
+		t.Fatalf("expected Bearer t1; got=%v", got)
+	}
+
+	retry, err := src.HandleUnauthorized(req, &http.Response{StatusCode: http.StatusUnauthorized, Header: http.Header{}})
+	if err != nil || !retry {
+		t.Fatalf("expected retry=true err=nil; got retry=%v err=%v", retry, err)
+	}
+
+	tok, err := src.Token(context.Background())
+	if err != nil {
+		t.Fatalf("Token() err=%v", err)
+	}
+	if tok != "t2" {
+		t.Fatalf("expected t2 after invalidation; got=%v", tok)
+	}
+
+	// Challenge com um token antigo não deve descartar o token renovado.
+	src.Invalidate(context.Background(), "t1")
+	tok, _ = src.Token(context.Background())
+	if tok != "t2" || tokenCalls.Load() != 2 {
+		t.Fatalf("stale invalidation should keep t2; got=%v calls=%d", tok, tokenCalls.Load())
+	}
+}
+
+func TestTokenSource_HandleUnauthorizedIgnoresOtherStatus(t *testing.T) {
+	t.Parallel()
+
+	src := NewTokenSource(http.DefaultClient, nil, Config[DefaultTokenResponse]{})
+	req, _ := http.NewRequest(http.MethodGet, "http://example.com", nil)
+	req.Header.Set("Authorization", "Bearer t1")
+
+	retry, _ := src.HandleUnauthorized(req, &http.Response{StatusCode: http.StatusForbidden, Header: http.Header{}})
+	if retry {
+		t.Fatal("403 without invalid_token challenge should not retry")
+	}
+
+	h := http.Header{}
+	h.Set("WWW-Authenticate", `Bearer realm="api", error="invalid_token"`)
+	retry, _ = src.HandleUnauthorized(req, &http.Response{StatusCode: http.StatusForbidden, Header: h})
+	if !retry {
+		t.Fatal("invalid_token challenge should retry")
+	}
+}
+
+func TestTokenSource_BackgroundRefresh(t *testing.T) {
+	t.Parallel()
+
+	var tokenCalls atomic.Int64
+	var failing atomic.Bool
+	tokenSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if failing.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		tokenCalls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token": fmt.Sprintf("t%d", tokenCalls.Load()),
+			"expires_in":   1,
+		})
+	}))
+	t.Cleanup(tokenSrv.Close)
+
+	src := NewTokenSource(http.DefaultClient, nil, Config[DefaultTokenResponse]{
+		TokenURL:          tokenSrv.URL,
+		ClientID:          "id",
+		ClientSecret:      "secret",
+		RefreshBefore:     100 * time.Millisecond,
+		BackgroundRefresh: true,
+		RefreshBackoff:    10 * time.Millisecond,
+	})
+	src.Start(context.Background())
+	t.Cleanup(src.Stop)
+
+	deadline := time.Now().Add(3 * time.Second)
+	for tokenCalls.Load() < 2 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if tokenCalls.Load() < 2 {
+		t.Fatalf("expected background refresh; got %d calls", tokenCalls.Load())
+	}
+
+	// Com o token endpoint fora do ar, o token ainda válido continua sendo servido.
+	failing.Store(true)
+	tok, err := src.Token(context.Background())
+	if err != nil {
+		t.Fatalf("Token() err=%v", err)
+	}
+	if tok == "" {
+		t.Fatal("expected previous token while refresh fails")
+	}
+
+	src.Stop()
+	calls := tokenCalls.Load()
+	time.Sleep(50 * time.Millisecond)
+	if tokenCalls.Load() != calls {
+		t.Fatal("expected no refresh after Stop")
+	}
+}
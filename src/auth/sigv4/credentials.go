@@ -0,0 +1,259 @@
+package sigv4
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Credentials são as credenciais AWS usadas na assinatura.
+type Credentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+
+	// Expires é zero para credenciais que não expiram.
+	Expires time.Time
+}
+
+// CredentialsProvider fornece credenciais para o Signer.
+type CredentialsProvider interface {
+	Retrieve(ctx context.Context) (Credentials, error)
+}
+
+// CredentialsProviderFunc adapta uma função para CredentialsProvider.
+type CredentialsProviderFunc func(ctx context.Context) (Credentials, error)
+
+func (f CredentialsProviderFunc) Retrieve(ctx context.Context) (Credentials, error) {
+	return f(ctx)
+}
+
+// StaticCredentials retorna sempre as mesmas credenciais.
+func StaticCredentials(accessKeyID, secretAccessKey, sessionToken string) CredentialsProvider {
+	return CredentialsProviderFunc(func(ctx context.Context) (Credentials, error) {
+		return Credentials{
+			AccessKeyID:     accessKeyID,
+			SecretAccessKey: secretAccessKey,
+			SessionToken:    sessionToken,
+		}, nil
+	})
+}
+
+// EnvCredentials lê AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY e AWS_SESSION_TOKEN a cada chamada.
+func EnvCredentials() CredentialsProvider {
+	return CredentialsProviderFunc(func(ctx context.Context) (Credentials, error) {
+		c := Credentials{
+			AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+			SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+		}
+		if c.AccessKeyID == "" || c.SecretAccessKey == "" {
+			return Credentials{}, errors.New("sigv4: AWS_ACCESS_KEY_ID/AWS_SECRET_ACCESS_KEY not set")
+		}
+		return c, nil
+	})
+}
+
+// cachedProvider reaproveita credenciais temporárias até 1 minuto antes de expirarem.
+type cachedProvider struct {
+	fetch func(ctx context.Context) (Credentials, error)
+	now   func() time.Time
+
+	mu    sync.Mutex
+	creds Credentials
+}
+
+func (p *cachedProvider) Retrieve(ctx context.Context) (Credentials, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.creds.AccessKeyID != "" && (p.creds.Expires.IsZero() || p.now().Add(time.Minute).Before(p.creds.Expires)) {
+		return p.creds, nil
+	}
+	creds, err := p.fetch(ctx)
+	if err != nil {
+		return Credentials{}, err
+	}
+	p.creds = creds
+	return creds, nil
+}
+
+// AssumeRoleConfig contém as configurações do provider de STS AssumeRole.
+type AssumeRoleConfig struct {
+	RoleARN     string
+	SessionName string
+	// Duration da sessão. Default: 1h.
+	Duration time.Duration
+	// Region do STS. Default: us-east-1.
+	Region string
+	// Endpoint sobrescreve a URL do STS (ex: VPC endpoint).
+	Endpoint string
+	// Source são as credenciais usadas para assinar a chamada ao STS. Default: EnvCredentials().
+	Source CredentialsProvider
+	// HTTPClient usado na chamada ao STS. Default: http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+// AssumeRoleCredentials obtém credenciais temporárias via STS AssumeRole, renovando antes de expirarem.
+func AssumeRoleCredentials(cfg AssumeRoleConfig) CredentialsProvider {
+	if cfg.Duration <= 0 {
+		cfg.Duration = time.Hour
+	}
+	if cfg.Region == "" {
+		cfg.Region = "us-east-1"
+	}
+	if cfg.Endpoint == "" {
+		cfg.Endpoint = "https://sts." + cfg.Region + ".amazonaws.com/"
+	}
+	if cfg.SessionName == "" {
+		cfg.SessionName = "goxios-" + strconv.FormatInt(time.Now().Unix(), 10)
+	}
+	if cfg.Source == nil {
+		cfg.Source = EnvCredentials()
+	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = http.DefaultClient
+	}
+	return &cachedProvider{now: time.Now, fetch: func(ctx context.Context) (Credentials, error) {
+		return assumeRole(ctx, cfg)
+	}}
+}
+
+func assumeRole(ctx context.Context, cfg AssumeRoleConfig) (Credentials, error) {
+	form := url.Values{}
+	form.Set("Action", "AssumeRole")
+	form.Set("Version", "2011-06-15")
+	form.Set("RoleArn", cfg.RoleARN)
+	form.Set("RoleSessionName", cfg.SessionName)
+	form.Set("DurationSeconds", strconv.Itoa(int(cfg.Duration.Seconds())))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, cfg.Endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return Credentials{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+
+	signer, err := NewSigner(Config{Service: "sts", Region: cfg.Region, Credentials: cfg.Source})
+	if err != nil {
+		return Credentials{}, err
+	}
+	if err := signer.Apply(req); err != nil {
+		return Credentials{}, err
+	}
+
+	resp, err := cfg.HTTPClient.Do(req)
+	if err != nil {
+		return Credentials{}, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return Credentials{}, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return Credentials{}, errors.New("sigv4: sts AssumeRole returned error: " + resp.Status + " body=" + string(body))
+	}
+
+	var out struct {
+		Credentials struct {
+			AccessKeyID     string    `xml:"AccessKeyId"`
+			SecretAccessKey string    `xml:"SecretAccessKey"`
+			SessionToken    string    `xml:"SessionToken"`
+			Expiration      time.Time `xml:"Expiration"`
+		} `xml:"AssumeRoleResult>Credentials"`
+	}
+	if err := xml.Unmarshal(body, &out); err != nil {
+		return Credentials{}, err
+	}
+	return Credentials{
+		AccessKeyID:     out.Credentials.AccessKeyID,
+		SecretAccessKey: out.Credentials.SecretAccessKey,
+		SessionToken:    out.Credentials.SessionToken,
+		Expires:         out.Credentials.Expiration,
+	}, nil
+}
+
+// InstanceRoleCredentials obtém as credenciais do role da instância EC2 via IMDSv2.
+// endpoint vazio usa http://169.254.169.254.
+func InstanceRoleCredentials(endpoint string, httpClient *http.Client) CredentialsProvider {
+	if endpoint == "" {
+		endpoint = "http://169.254.169.254"
+	}
+	endpoint = strings.TrimSuffix(endpoint, "/")
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 5 * time.Second}
+	}
+	return &cachedProvider{now: time.Now, fetch: func(ctx context.Context) (Credentials, error) {
+		return instanceRole(ctx, endpoint, httpClient)
+	}}
+}
+
+func instanceRole(ctx context.Context, endpoint string, httpClient *http.Client) (Credentials, error) {
+	imds := func(method, path string, header http.Header) (string, error) {
+		req, err := http.NewRequestWithContext(ctx, method, endpoint+path, nil)
+		if err != nil {
+			return "", err
+		}
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return "", err
+		}
+		defer resp.Body.Close()
+		b, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return "", err
+		}
+		if resp.StatusCode != http.StatusOK {
+			return "", errors.New("sigv4: imds " + path + " returned " + resp.Status)
+		}
+		return strings.TrimSpace(string(b)), nil
+	}
+
+	token, err := imds(http.MethodPut, "/latest/api/token", http.Header{"X-Aws-Ec2-Metadata-Token-Ttl-Seconds": {"21600"}})
+	if err != nil {
+		return Credentials{}, err
+	}
+	auth := http.Header{"X-Aws-Ec2-Metadata-Token": {token}}
+
+	roles, err := imds(http.MethodGet, "/latest/meta-data/iam/security-credentials/", auth)
+	if err != nil {
+		return Credentials{}, err
+	}
+	role, _, _ := strings.Cut(roles, "\n")
+	if role == "" {
+		return Credentials{}, errors.New("sigv4: no instance role attached")
+	}
+
+	raw, err := imds(http.MethodGet, "/latest/meta-data/iam/security-credentials/"+role, auth)
+	if err != nil {
+		return Credentials{}, err
+	}
+	var out struct {
+		AccessKeyID     string    `json:"AccessKeyId"`
+		SecretAccessKey string    `json:"SecretAccessKey"`
+		Token           string    `json:"Token"`
+		Expiration      time.Time `json:"Expiration"`
+	}
+	if err := json.Unmarshal([]byte(raw), &out); err != nil {
+		return Credentials{}, err
+	}
+	return Credentials{
+		AccessKeyID:     out.AccessKeyID,
+		SecretAccessKey: out.SecretAccessKey,
+		SessionToken:    out.Token,
+		Expires:         out.Expiration,
+	}, nil
+}
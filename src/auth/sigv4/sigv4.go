@@ -0,0 +1,244 @@
+package sigv4
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	algorithm       = "AWS4-HMAC-SHA256"
+	timeFormat      = "20060102T150405Z"
+	dateFormat      = "20060102"
+	emptySHA256     = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	unsignedPayload = "UNSIGNED-PAYLOAD"
+)
+
+// Config contém as configurações do signer SigV4.
+type Config struct {
+	// Service é o nome do serviço no escopo da assinatura (ex: "s3", "execute-api").
+	Service string
+	// Region é a região AWS (ex: "us-east-1").
+	Region string
+	// Credentials fornece as credenciais. Default: EnvCredentials().
+	Credentials CredentialsProvider
+
+	// UnsignedPayload assina com "UNSIGNED-PAYLOAD" em vez do hash do body.
+	// Usado automaticamente quando o body não pode ser relido (streaming).
+	UnsignedPayload bool
+
+	// Now existe pra testes; se nil usa time.Now.
+	Now func() time.Time
+}
+
+// Signer assina requests com AWS Signature Version 4.
+type Signer struct {
+	cfg Config
+}
+
+// NewSigner cria um novo Signer.
+func NewSigner(cfg Config) (*Signer, error) {
+	if cfg.Service == "" || cfg.Region == "" {
+		return nil, errors.New("sigv4: service and region are required")
+	}
+	if cfg.Credentials == nil {
+		cfg.Credentials = EnvCredentials()
+	}
+	if cfg.Now == nil {
+		cfg.Now = time.Now
+	}
+	return &Signer{cfg: cfg}, nil
+}
+
+// Apply assina a request. Deve rodar depois que headers e body estão definidos,
+// já que ambos fazem parte da assinatura.
+func (s *Signer) Apply(req *http.Request) error {
+	if s == nil || req == nil {
+		return nil
+	}
+	creds, err := s.cfg.Credentials.Retrieve(req.Context())
+	if err != nil {
+		return err
+	}
+
+	payloadHash, err := s.payloadHash(req)
+	if err != nil {
+		return err
+	}
+
+	now := s.cfg.Now().UTC()
+	amzDate := now.Format(timeFormat)
+
+	req.Header.Del("Authorization")
+	req.Header.Set("X-Amz-Date", amzDate)
+	if creds.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", creds.SessionToken)
+	}
+	if s.cfg.Service == "s3" || payloadHash == unsignedPayload {
+		req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	}
+
+	signedHeaders, canonicalHeaders := canonicalHeaders(req)
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		canonicalURI(req.URL, s.cfg.Service != "s3"),
+		canonicalQuery(req.URL),
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := now.Format(dateFormat) + "/" + s.cfg.Region + "/" + s.cfg.Service + "/aws4_request"
+	stringToSign := algorithm + "\n" + amzDate + "\n" + scope + "\n" + hexSHA256([]byte(canonicalRequest))
+
+	key := hmacSHA256([]byte("AWS4"+creds.SecretAccessKey), now.Format(dateFormat))
+	key = hmacSHA256(key, s.cfg.Region)
+	key = hmacSHA256(key, s.cfg.Service)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", algorithm+
+		" Credential="+creds.AccessKeyID+"/"+scope+
+		", SignedHeaders="+signedHeaders+
+		", Signature="+signature)
+	return nil
+}
+
+func (s *Signer) payloadHash(req *http.Request) (string, error) {
+	if s.cfg.UnsignedPayload {
+		return unsignedPayload, nil
+	}
+	if req.Body == nil || req.Body == http.NoBody {
+		return emptySHA256, nil
+	}
+	if req.GetBody == nil {
+		// Body não pode ser relido sem consumir o stream.
+		return unsignedPayload, nil
+	}
+	rc, err := req.GetBody()
+	if err != nil {
+		return "", err
+	}
+	defer rc.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, rc); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// canonicalHeaders assina host, content-type, content-md5 e os headers x-amz-*.
+func canonicalHeaders(req *http.Request) (string, string) {
+	headers := map[string]string{"host": canonicalHost(req)}
+	for k, v := range req.Header {
+		lk := strings.ToLower(k)
+		if lk == "content-type" || lk == "content-md5" || strings.HasPrefix(lk, "x-amz-") {
+			vals := make([]string, len(v))
+			for i, vv := range v {
+				vals[i] = strings.Join(strings.Fields(vv), " ")
+			}
+			headers[lk] = strings.Join(vals, ",")
+		}
+	}
+
+	names := make([]string, 0, len(headers))
+	for k := range headers {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, k := range names {
+		b.WriteString(k + ":" + headers[k] + "\n")
+	}
+	return strings.Join(names, ";"), b.String()
+}
+
+func canonicalHost(req *http.Request) string {
+	host := req.Host
+	if host == "" {
+		host = req.URL.Host
+	}
+	if h, port, err := net.SplitHostPort(host); err == nil {
+		if (req.URL.Scheme == "https" && port == "443") || (req.URL.Scheme == "http" && port == "80") {
+			return h
+		}
+	}
+	return host
+}
+
+// canonicalURI codifica cada segmento do path; serviços que não são S3 usam codificação dupla.
+func canonicalURI(u *url.URL, doubleEncode bool) string {
+	path := u.Path
+	if path == "" {
+		return "/"
+	}
+	segments := strings.Split(path, "/")
+	for i, seg := range segments {
+		seg = escape(seg)
+		if doubleEncode {
+			seg = escape(seg)
+		}
+		segments[i] = seg
+	}
+	return strings.Join(segments, "/")
+}
+
+func canonicalQuery(u *url.URL) string {
+	q := u.Query()
+	keys := make([]string, 0, len(q))
+	for k := range q {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return escape(keys[i]) < escape(keys[j]) })
+
+	var pairs []string
+	for _, k := range keys {
+		vals := make([]string, len(q[k]))
+		for i, v := range q[k] {
+			vals[i] = escape(v)
+		}
+		sort.Strings(vals)
+		for _, v := range vals {
+			pairs = append(pairs, escape(k)+"="+v)
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+// escape aplica a codificação de URI do SigV4: só A-Z, a-z, 0-9, '-', '_', '.', '~' ficam sem escape.
+func escape(s string) string {
+	const hexUpper = "0123456789ABCDEF"
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if ('A' <= c && c <= 'Z') || ('a' <= c && c <= 'z') || ('0' <= c && c <= '9') ||
+			c == '-' || c == '_' || c == '.' || c == '~' {
+			b.WriteByte(c)
+			continue
+		}
+		b.WriteByte('%')
+		b.WriteByte(hexUpper[c>>4])
+		b.WriteByte(hexUpper[c&15])
+	}
+	return b.String()
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+func hexSHA256(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,121 @@
+package sigv4
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func exampleSigner(t *testing.T, service string) *Signer {
+	t.Helper()
+	s, err := NewSigner(Config{
+		Service:     service,
+		Region:      "us-east-1",
+		Credentials: StaticCredentials("AKIDEXAMPLE", "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY", ""),
+		Now:         func() time.Time { return time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC) },
+	})
+	if err != nil {
+		t.Fatalf("NewSigner() err=%v", err)
+	}
+	return s
+}
+
+// Vetores da suíte de testes oficial do SigV4.
+func TestSigner_Vectors(t *testing.T) {
+	t.Run("GetVanilla", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "https://example.amazonaws.com/", nil)
+		if err := exampleSigner(t, "service").Apply(req); err != nil {
+			t.Fatalf("Apply() err=%v", err)
+		}
+		want := "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, " +
+			"SignedHeaders=host;x-amz-date, " +
+			"Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31"
+		if got := req.Header.Get("Authorization"); got != want {
+			t.Fatalf("Authorization =\n%s\nwant\n%s", got, want)
+		}
+	})
+
+	t.Run("IAMListUsers", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "https://iam.amazonaws.com/?Action=ListUsers&Version=2010-05-08", nil)
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+		if err := exampleSigner(t, "iam").Apply(req); err != nil {
+			t.Fatalf("Apply() err=%v", err)
+		}
+		if got := req.Header.Get("Authorization"); !strings.HasSuffix(got, "Signature=5d672d79c15b13162d9279b0855cfba6789a8edb4c82c400e06b5924a6f2b5d7") {
+			t.Fatalf("unexpected Authorization: %s", got)
+		}
+	})
+}
+
+func TestSigner_Payload(t *testing.T) {
+	s := exampleSigner(t, "s3")
+
+	req, _ := http.NewRequest(http.MethodPut, "https://bucket.s3.amazonaws.com/my key", bytes.NewReader([]byte("hello")))
+	if err := s.Apply(req); err != nil {
+		t.Fatalf("Apply() err=%v", err)
+	}
+	if got := req.Header.Get("X-Amz-Content-Sha256"); got != hexSHA256([]byte("hello")) {
+		t.Fatalf("unexpected payload hash: %s", got)
+	}
+
+	// Body sem GetBody (stream) é assinado como UNSIGNED-PAYLOAD.
+	req, _ = http.NewRequest(http.MethodPut, "https://bucket.s3.amazonaws.com/key", nil)
+	req.Body = io.NopCloser(bytes.NewReader([]byte("stream")))
+	if err := s.Apply(req); err != nil {
+		t.Fatalf("Apply() err=%v", err)
+	}
+	if got := req.Header.Get("X-Amz-Content-Sha256"); got != unsignedPayload {
+		t.Fatalf("expected UNSIGNED-PAYLOAD; got %s", got)
+	}
+}
+
+func TestCanonicalURI(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodGet, "https://example.com/a b/c", nil)
+	if got := canonicalURI(req.URL, false); got != "/a%20b/c" {
+		t.Errorf("single encode = %s", got)
+	}
+	if got := canonicalURI(req.URL, true); got != "/a%2520b/c" {
+		t.Errorf("double encode = %s", got)
+	}
+}
+
+func TestInstanceRoleCredentials(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/latest/api/token" {
+			_, _ = w.Write([]byte("imds-token"))
+			return
+		}
+		if r.Header.Get("X-Aws-Ec2-Metadata-Token") != "imds-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/latest/meta-data/iam/security-credentials/":
+			_, _ = w.Write([]byte("my-role"))
+		case "/latest/meta-data/iam/security-credentials/my-role":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"AccessKeyId":     "AKID",
+				"SecretAccessKey": "secret",
+				"Token":           "session",
+				"Expiration":      time.Now().Add(time.Hour).Format(time.RFC3339),
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	creds, err := InstanceRoleCredentials(srv.URL, srv.Client()).Retrieve(context.Background())
+	if err != nil {
+		t.Fatalf("Retrieve() err=%v", err)
+	}
+	if creds.AccessKeyID != "AKID" || creds.SessionToken != "session" || creds.Expires.IsZero() {
+		t.Fatalf("unexpected credentials: %+v", creds)
+	}
+}
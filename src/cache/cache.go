@@ -10,3 +10,9 @@ type TokenCache interface {
 	Get(ctx context.Context, key string) (string, error)
 	Set(ctx context.Context, key string, value string, ttl time.Duration) error
 }
+
+// TokenCacheDeleter é implementado por caches que suportam remoção explícita de chaves.
+// Usado para invalidar tokens revogados antes do TTL.
+type TokenCacheDeleter interface {
+	Delete(ctx context.Context, key string) error
+}
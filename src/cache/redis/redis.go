@@ -32,3 +32,6 @@ func (r *RedisCache) Set(ctx context.Context, key string, value string, ttl time
 	return r.client.Set(ctx, key, value, ttl).Err()
 }
 
+func (r *RedisCache) Delete(ctx context.Context, key string) error {
+	return r.client.Del(ctx, key).Err()
+}
@@ -34,3 +34,10 @@ func TestRedisCache_Offline(t *testing.T) {
 	}
 }
 
+func TestRedisCache_DeleteOffline(t *testing.T) {
+	cache := NewRedisCache("localhost:1")
+
+	if err := cache.Delete(context.Background(), "test-key"); err == nil {
+		t.Error("expected error when trying Delete on offline redis")
+	}
+}
@@ -3,11 +3,34 @@ package goxios_errors
 import "errors"
 
 var (
-	ErrInvalidBaseURL  = errors.New("baseURL must be absolute (e.g. https://api.example.com)")
-	ErrEmptyURL        = errors.New("empty url")
-	ErrRelativeURL     = errors.New("relative url without baseURL configured")
-	ErrNilClient       = errors.New("request/client is nil")
-	ErrInvalidTimeout  = errors.New("invalid timeout")
-	ErrEmptyHeaderKey  = errors.New("empty header key")
-	ErrUnsupportedAuth = errors.New("unsupported auth type")
+	ErrInvalidBaseURL         = errors.New("baseURL must be absolute (e.g. https://api.example.com)")
+	ErrEmptyURL               = errors.New("empty url")
+	ErrRelativeURL            = errors.New("relative url without baseURL configured")
+	ErrNilClient              = errors.New("request/client is nil")
+	ErrInvalidTimeout         = errors.New("invalid timeout")
+	ErrEmptyHeaderKey         = errors.New("empty header key")
+	ErrUnsupportedAuth        = errors.New("unsupported auth type")
+	ErrEmptyHost              = errors.New("empty host")
+	ErrEmptyPins              = errors.New("no certificate pins provided")
+	ErrEmptyCertificate       = errors.New("empty certificate or key")
+	ErrInvalidPoolConfig      = errors.New("invalid transport pool configuration")
+	ErrUnsupportedProxyScheme = errors.New("unsupported proxy scheme")
+	ErrInvalidAddress         = errors.New("invalid network address")
+	ErrInvalidHTTP2Config     = errors.New("invalid HTTP/2 configuration")
+
+	ErrInvalidTLSVersion      = errors.New("invalid TLS version or min/max range")
+	ErrUnsupportedCipherSuite = errors.New("unsupported TLS cipher suite")
+
+	ErrKeyPasswordRequired  = errors.New("encrypted private key requires a password")
+	ErrIncorrectKeyPassword = errors.New("incorrect password for private key or PKCS#12 bundle")
+
+	ErrDialTimeout         = errors.New("timeout dialing connection")
+	ErrTLSHandshakeTimeout = errors.New("timeout during TLS handshake")
+	ErrFirstByteTimeout    = errors.New("timeout waiting for first response byte")
+	ErrBodyReadTimeout     = errors.New("timeout waiting for response body data")
+
+	ErrInvalidDeadlineFormat   = errors.New("invalid deadline propagation format")
+	ErrDeadlineBudgetExhausted = errors.New("request deadline budget exhausted before sending")
+
+	ErrCertificatePinMismatch = errors.New("server certificate does not match pinned public keys")
 )
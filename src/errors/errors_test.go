@@ -13,6 +13,24 @@ func TestErrors(t *testing.T) {
 		ErrInvalidTimeout,
 		ErrEmptyHeaderKey,
 		ErrUnsupportedAuth,
+		ErrEmptyHost,
+		ErrEmptyPins,
+		ErrEmptyCertificate,
+		ErrInvalidPoolConfig,
+		ErrUnsupportedProxyScheme,
+		ErrInvalidAddress,
+		ErrInvalidHTTP2Config,
+		ErrInvalidTLSVersion,
+		ErrUnsupportedCipherSuite,
+		ErrKeyPasswordRequired,
+		ErrIncorrectKeyPassword,
+		ErrDialTimeout,
+		ErrTLSHandshakeTimeout,
+		ErrFirstByteTimeout,
+		ErrBodyReadTimeout,
+		ErrInvalidDeadlineFormat,
+		ErrDeadlineBudgetExhausted,
+		ErrCertificatePinMismatch,
 	}
 
 	for _, err := range errs {
@@ -0,0 +1,106 @@
+package request
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	goxios_errors "github.com/drummerzzz/goxios/src/errors"
+)
+
+// DeadlineFormat define como o tempo restante é escrito no header de propagação.
+type DeadlineFormat int
+
+const (
+	// DeadlineMillis escreve milissegundos inteiros (ex: "1500").
+	DeadlineMillis DeadlineFormat = iota
+	// DeadlineSeconds escreve segundos com três casas decimais (ex: "1.500").
+	DeadlineSeconds
+	// DeadlineGRPC segue o formato do header grpc-timeout (ex: "1500m", "30S").
+	DeadlineGRPC
+)
+
+// Valid indica se o formato é conhecido.
+func (f DeadlineFormat) Valid() bool {
+	return f >= DeadlineMillis && f <= DeadlineGRPC
+}
+
+// DeadlinePropagation envia ao servidor o tempo restante da request, descontada a margem.
+type DeadlinePropagation struct {
+	Header string
+	// Margin é descontada do tempo restante para cobrir rede e processamento da resposta.
+	Margin time.Duration
+	Format DeadlineFormat
+}
+
+// NoDeadlinePropagation não envia o header de deadline nessa request (ex: APIs externas).
+func (r *Request) NoDeadlinePropagation() *Request {
+	if r == nil {
+		return r
+	}
+	r.Deadline = nil
+	return r
+}
+
+// applyDeadline escreve o tempo restante no header configurado. O limite é o menor entre o
+// deadline do contexto e o timeout total da request; sem nenhum dos dois, nada é enviado.
+func (r *Request) applyDeadline(ctx context.Context, req *http.Request) error {
+	if r.Deadline == nil {
+		return nil
+	}
+	budget, ok := r.remainingBudget(ctx)
+	if !ok {
+		return nil
+	}
+	budget -= r.Deadline.Margin
+	if budget < time.Millisecond {
+		return errors.Join(goxios_errors.ErrDeadlineBudgetExhausted, context.DeadlineExceeded)
+	}
+	req.Header.Set(r.Deadline.Header, formatDeadline(budget, r.Deadline.Format))
+	return nil
+}
+
+func (r *Request) remainingBudget(ctx context.Context) (time.Duration, bool) {
+	timeout := r.HTTPClient.Timeout
+	if r.RequestTimeout != nil {
+		timeout = *r.RequestTimeout
+	}
+	budget, ok := timeout, timeout > 0
+	if deadline, has := ctx.Deadline(); has {
+		if left := time.Until(deadline); !ok || left < budget {
+			budget, ok = left, true
+		}
+	}
+	return budget, ok
+}
+
+// grpcUnits em ordem crescente; o grpc-timeout aceita no máximo 8 dígitos.
+var grpcUnits = []struct {
+	unit   time.Duration
+	suffix string
+}{
+	{time.Nanosecond, "n"},
+	{time.Microsecond, "u"},
+	{time.Millisecond, "m"},
+	{time.Second, "S"},
+	{time.Minute, "M"},
+	{time.Hour, "H"},
+}
+
+func formatDeadline(d time.Duration, format DeadlineFormat) string {
+	switch format {
+	case DeadlineSeconds:
+		return strconv.FormatFloat(d.Seconds(), 'f', 3, 64)
+	case DeadlineGRPC:
+		for _, u := range grpcUnits {
+			if v := d / u.unit; v < 1e8 {
+				return strconv.FormatInt(int64(v), 10) + u.suffix
+			}
+		}
+		return strconv.FormatInt(int64(d/time.Hour), 10) + "H"
+	default:
+		return strconv.FormatInt(d.Milliseconds(), 10)
+	}
+}
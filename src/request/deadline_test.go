@@ -0,0 +1,95 @@
+package request
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	goxios_errors "github.com/drummerzzz/goxios/src/errors"
+)
+
+func TestFormatDeadline(t *testing.T) {
+	tests := []struct {
+		d      time.Duration
+		format DeadlineFormat
+		want   string
+	}{
+		{1500 * time.Millisecond, DeadlineMillis, "1500"},
+		{1500 * time.Millisecond, DeadlineSeconds, "1.500"},
+		{50 * time.Millisecond, DeadlineGRPC, "50000000n"},
+		{1500 * time.Millisecond, DeadlineGRPC, "1500000u"},
+		{2 * time.Minute, DeadlineGRPC, "120000m"},
+		{40 * time.Hour, DeadlineGRPC, "144000S"},
+	}
+	for _, tt := range tests {
+		if got := formatDeadline(tt.d, tt.format); got != tt.want {
+			t.Errorf("formatDeadline(%v, %d) = %q, want %q", tt.d, tt.format, got, tt.want)
+		}
+	}
+}
+
+func TestRequest_Do_PropagatesDeadline(t *testing.T) {
+	var calls atomic.Int64
+	var got atomic.Value
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		got.Store(r.Header.Get("X-Request-Timeout"))
+	}))
+	defer srv.Close()
+
+	newReq := func() *Request {
+		return &Request{
+			HTTPClient: srv.Client(),
+			Method:     http.MethodGet,
+			RawURL:     srv.URL,
+			Deadline:   &DeadlinePropagation{Header: "X-Request-Timeout", Margin: 100 * time.Millisecond},
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	resp, err := newReq().Do(ctx)
+	if err != nil {
+		t.Fatalf("Do() err=%v", err)
+	}
+	resp.Body.Close()
+	ms, err := strconv.Atoi(got.Load().(string))
+	if err != nil || ms <= 1500 || ms > 1900 {
+		t.Fatalf("expected remaining budget minus margin; got=%q", got.Load())
+	}
+
+	// O timeout da request limita o orçamento quando é menor que o do contexto.
+	resp, err = newReq().Timeout(500 * time.Millisecond).Do(ctx)
+	if err != nil {
+		t.Fatalf("Do() err=%v", err)
+	}
+	resp.Body.Close()
+	if ms, _ := strconv.Atoi(got.Load().(string)); ms > 400 {
+		t.Fatalf("expected budget bounded by request timeout; got=%q", got.Load())
+	}
+
+	short, cancelShort := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancelShort()
+	before := calls.Load()
+	_, err = newReq().Do(short)
+	if !errors.Is(err, goxios_errors.ErrDeadlineBudgetExhausted) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected ErrDeadlineBudgetExhausted; got=%v", err)
+	}
+	if calls.Load() != before {
+		t.Fatalf("exhausted budget must not reach the server")
+	}
+
+	resp, err = newReq().Do()
+	if err != nil {
+		t.Fatalf("Do() err=%v", err)
+	}
+	resp.Body.Close()
+	if got.Load().(string) != "" {
+		t.Fatalf("expected no header without deadline; got=%q", got.Load())
+	}
+}
@@ -3,13 +3,21 @@ package request
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
 	"io"
+	"net"
 	"net/http"
 	"net/url"
 	"strings"
 	"time"
 
+	"github.com/drummerzzz/goxios/internal/socks5"
 	"github.com/drummerzzz/goxios/internal/tlsutil"
+	"github.com/drummerzzz/goxios/internal/transportpool"
 	"github.com/drummerzzz/goxios/src/response"
 	"go.uber.org/zap"
 )
@@ -18,23 +26,167 @@ import (
 type Certificate struct {
 	MtlsCertBase64 string
 	MtlsKeyBase64  string
+
+	// MtlsPKCS12Base64 é um bundle PKCS#12 (.p12/.pfx) em Base64, alternativo ao par cert/key.
+	MtlsPKCS12Base64 string
+	// MtlsPassword decifra o bundle PKCS#12 ou uma chave PKCS#8 criptografada.
+	MtlsPassword string
+}
+
+// fingerprint identifica o material do certificado sem decodificá-lo.
+func (c *Certificate) fingerprint() string {
+	h := sha256.New()
+	for _, v := range []string{c.MtlsCertBase64, c.MtlsKeyBase64, c.MtlsPKCS12Base64, c.MtlsPassword} {
+		h.Write([]byte(v))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// load decodifica o certificado de cliente.
+func (c *Certificate) load() (tls.Certificate, error) {
+	if c.MtlsPKCS12Base64 != "" {
+		data, err := base64.StdEncoding.DecodeString(c.MtlsPKCS12Base64)
+		if err != nil {
+			return tls.Certificate{}, err
+		}
+		return tlsutil.LoadPKCS12(data, c.MtlsPassword)
+	}
+	if c.MtlsPassword == "" {
+		return tlsutil.LoadCertificateFromBase64(c.MtlsCertBase64, c.MtlsKeyBase64)
+	}
+	certPEM, err := base64.StdEncoding.DecodeString(c.MtlsCertBase64)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	keyPEM, err := base64.StdEncoding.DecodeString(c.MtlsKeyBase64)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	return tlsutil.ParseKeyPair(certPEM, keyPEM, c.MtlsPassword)
 }
 
 // AuthFunc aplica autenticação na request.
 type AuthFunc func(req *http.Request) error
 
+// BodyBytes retorna uma cópia do body da request sem consumi-lo, para uso em AuthFunc
+// (ex: assinaturas que cobrem o body). Retorna nil quando não há body.
+func BodyBytes(req *http.Request) ([]byte, error) {
+	if req == nil || req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	if req.GetBody == nil {
+		return nil, errors.New("goxios request: body is not rewindable")
+	}
+	rc, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
+
+// transport retorna o transport da request. Com MTLS ou proxy próprios, usa um transport
+// derivado do client, reaproveitado via TransportPool quando configurado.
+func (r *Request) transport() (*http.Transport, error) {
+	overrideProxy := r.ProxyURL != "" || r.DirectConnection
+	if r.MtlsCert == nil && !overrideProxy {
+		return r.Transport, nil
+	}
+
+	var proxyURL *url.URL
+	if r.ProxyURL != "" {
+		u, err := url.Parse(r.ProxyURL)
+		if err != nil {
+			return nil, err
+		}
+		proxyURL = u
+	}
+
+	build := func() (*http.Transport, error) {
+		tr := r.Transport.Clone()
+		if r.MtlsCert != nil {
+			cert, err := r.MtlsCert.load()
+			if err != nil {
+				return nil, err
+			}
+			tr.TLSClientConfig = tlsutil.WithClientCertificate(r.Transport.TLSClientConfig, cert)
+		}
+		if overrideProxy {
+			// Parte do dial base do client, sem o SOCKS5 e os headers do proxy configurados nele.
+			tr.Proxy = nil
+			tr.GetProxyConnectHeader = nil
+			tr.ProxyConnectHeader = nil
+			tr.DialContext = r.BaseDial
+			if proxyURL != nil && socks5.IsSOCKS5(proxyURL) {
+				d, err := socks5.FromURL(proxyURL)
+				if err != nil {
+					return nil, err
+				}
+				d.Forward = r.BaseDial
+				tr.DialContext = d.DialContext
+			} else if proxyURL != nil {
+				tr.Proxy = http.ProxyURL(proxyURL)
+			}
+		}
+		return tr, nil
+	}
+	if r.TransportPool == nil {
+		return build()
+	}
+
+	var key strings.Builder
+	if r.MtlsCert != nil {
+		key.WriteString("mtls:" + r.MtlsCert.fingerprint() + "|")
+	}
+	if r.DirectConnection {
+		key.WriteString("proxy:direct")
+	} else if proxyURL != nil {
+		key.WriteString("proxy:" + proxyURL.String())
+	}
+	return r.TransportPool.Get(key.String(), build)
+}
+
+// DialFunc abre as conexões do transport (ver http.Transport.DialContext).
+type DialFunc func(ctx context.Context, network, addr string) (net.Conn, error)
+
+// ChallengeFunc é chamada quando a resposta indica falha de autenticação (ex: 401).
+// Retorna true quando a request deve ser repetida uma vez com a auth reaplicada.
+type ChallengeFunc func(req *http.Request, resp *http.Response) (bool, error)
+
 type Request struct {
 	Method        string
 	RawURL        string
 	BodyData      []byte
 	CustomHeaders http.Header
 	Auth          AuthFunc
+	AuthChallenge ChallengeFunc
 	MtlsCert      *Certificate
 	HTTPClient    *http.Client
 	Transport     *http.Transport
 	Logger        *zap.Logger
 	BaseURL       *url.URL
 
+	// TransportPool reaproveita entre chamadas os transports de requests com MTLS ou proxy
+	// próprios. Se nil, cada request cria um transport novo.
+	TransportPool *transportpool.Pool
+	// ProxyURL envia a request por esse proxy (http, https, socks5 ou socks5h) em vez do
+	// proxy do client.
+	ProxyURL string
+	// DirectConnection ignora o proxy do client.
+	DirectConnection bool
+	// BaseDial é o dial do client sem SOCKS5, usado quando a request troca o proxy.
+	BaseDial DialFunc
+	// ProxyHeader são headers para o proxy HTTP (ex: Proxy-Authorization), enviados apenas
+	// quando a request passa por ele.
+	ProxyHeader http.Header
+	// RequestTimeout substitui o timeout total do client quando não é nil (0 = sem limite).
+	RequestTimeout *time.Duration
+	// Phases limita fases específicas da request (dial, TLS, primeiro byte, leitura do body).
+	Phases PhaseTimeouts
+	// Deadline propaga o tempo restante da request ao servidor via header. Se nil, desabilitado.
+	Deadline *DeadlinePropagation
+
 	// Erros pré-definidos para evitar ciclo de importação
 	ErrNilClient   error
 	ErrEmptyURL    error
@@ -67,6 +219,16 @@ func (r *Request) WithAuth(auth AuthFunc) *Request {
 		return r
 	}
 	r.Auth = auth
+	r.AuthChallenge = nil
+	return r
+}
+
+// WithAuthChallenge define o tratamento de respostas 401 para a auth dessa request.
+func (r *Request) WithAuthChallenge(challenge ChallengeFunc) *Request {
+	if r == nil {
+		return r
+	}
+	r.AuthChallenge = challenge
 	return r
 }
 
@@ -79,6 +241,27 @@ func (r *Request) MTLS(cert *Certificate) *Request {
 	return r
 }
 
+// Proxy envia apenas essa request pelo proxy informado (http, https, socks5 ou socks5h),
+// ignorando o proxy e as exceções do client.
+func (r *Request) Proxy(rawURL string) *Request {
+	if r == nil {
+		return r
+	}
+	r.ProxyURL = rawURL
+	r.DirectConnection = false
+	return r
+}
+
+// NoProxy faz a request conectar diretamente, ignorando o proxy do client.
+func (r *Request) NoProxy() *Request {
+	if r == nil {
+		return r
+	}
+	r.ProxyURL = ""
+	r.DirectConnection = true
+	return r
+}
+
 func (r *Request) Body(body []byte) *Request {
 	if r == nil {
 		return r
@@ -123,6 +306,75 @@ func (r *Request) Do(ctx ...context.Context) (*response.Response, error) {
 		return nil, err
 	}
 
+	if err := r.validateTimeouts(); err != nil {
+		return nil, err
+	}
+	tr, err := r.transport()
+	if err != nil {
+		return nil, err
+	}
+	httpClient := r.httpClientFor(tr)
+
+	var watcher *phaseWatcher
+	if r.Phases.enabled() {
+		c, watcher = withPhaseTimeouts(c, r.Phases)
+		// Sem resposta, o watcher é liberado aqui; com resposta, no Close do body.
+		defer func() {
+			if watcher != nil {
+				watcher.done()
+			}
+		}()
+	}
+
+	req, err := r.newHTTPRequest(c, finalURL, tr)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	resp, err := httpClient.Do(req)
+	if err == nil && r.AuthChallenge != nil && isAuthChallenge(resp) {
+		retry, cerr := r.AuthChallenge(req, resp)
+		if cerr != nil {
+			resp.Body.Close()
+			return nil, cerr
+		}
+		if retry {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+			req, err = r.newHTTPRequest(c, finalURL, tr)
+			if err != nil {
+				return nil, err
+			}
+			resp, err = httpClient.Do(req)
+		}
+	}
+	if err != nil {
+		if watcher != nil {
+			err = watcher.wrapErr(c, err)
+		}
+		if r.Logger != nil {
+			r.Logger.Debug(
+				"goxios request: error executing request",
+				zap.String("method", r.Method),
+				zap.String("url", finalURL),
+				zap.Duration("duration", time.Since(start)),
+				zap.Error(err),
+			)
+		}
+		return nil, err
+	}
+	if watcher != nil {
+		resp.Body = newWatchedBody(c, resp.Body, watcher)
+		watcher = nil
+	}
+
+	return &response.Response{Response: resp, Logger: r.Logger}, nil
+}
+
+// newHTTPRequest monta a http.Request com body, headers e auth aplicados.
+// É chamada novamente quando a request precisa ser repetida após um challenge de auth.
+func (r *Request) newHTTPRequest(c context.Context, finalURL string, tr *http.Transport) (*http.Request, error) {
 	var bodyReader io.Reader
 	if r.BodyData != nil {
 		bodyReader = bytes.NewReader(r.BodyData)
@@ -140,6 +392,12 @@ func (r *Request) Do(ctx ...context.Context) (*response.Response, error) {
 			req.Header.Add(k, vv)
 		}
 	}
+	if err := r.applyProxyHeader(req, tr); err != nil {
+		return nil, err
+	}
+	if err := r.applyDeadline(c, req); err != nil {
+		return nil, err
+	}
 
 	if r.Auth != nil {
 		if err := r.Auth(req); err != nil {
@@ -154,35 +412,30 @@ func (r *Request) Do(ctx ...context.Context) (*response.Response, error) {
 			return nil, err
 		}
 	}
+	return req, nil
+}
 
-	httpClient := r.HTTPClient
-	if r.MtlsCert != nil {
-		tr := r.Transport.Clone()
-		tlsConfig, err := tlsutil.LoadTLSConfigFromBase64(r.MtlsCert.MtlsCertBase64, r.MtlsCert.MtlsKeyBase64)
-		if err != nil {
-			return nil, err
-		}
-		tr.TLSClientConfig = tlsConfig
-		httpClient = &http.Client{
-			Transport: tr,
-			Timeout:   r.HTTPClient.Timeout,
-		}
+// applyProxyHeader adiciona ProxyHeader em requests HTTP que passam pelo proxy do client. Em
+// HTTPS os headers vão no CONNECT (Transport.ProxyConnectHeader) e não chegam ao servidor de
+// destino. Um proxy definido na request nunca recebe os headers do proxy do client.
+func (r *Request) applyProxyHeader(req *http.Request, tr *http.Transport) error {
+	if len(r.ProxyHeader) == 0 || r.ProxyURL != "" || r.DirectConnection ||
+		req.URL.Scheme != "http" || tr == nil || tr.Proxy == nil {
+		return nil
 	}
-
-	start := time.Now()
-	resp, err := httpClient.Do(req)
+	proxyURL, err := tr.Proxy(req)
 	if err != nil {
-		if r.Logger != nil {
-			r.Logger.Debug(
-				"goxios request: error executing request",
-				zap.String("method", r.Method),
-				zap.String("url", finalURL),
-				zap.Duration("duration", time.Since(start)),
-				zap.Error(err),
-			)
-		}
-		return nil, err
+		return err
+	}
+	if proxyURL == nil || (proxyURL.Scheme != "http" && proxyURL.Scheme != "https") {
+		return nil
 	}
+	for k, v := range r.ProxyHeader {
+		req.Header[k] = append([]string(nil), v...)
+	}
+	return nil
+}
 
-	return &response.Response{Response: resp, Logger: r.Logger}, nil
+func isAuthChallenge(resp *http.Response) bool {
+	return resp.StatusCode == http.StatusUnauthorized || resp.Header.Get("WWW-Authenticate") != ""
 }
@@ -2,7 +2,12 @@ package request
 
 import (
 	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"strings"
+	"sync/atomic"
 	"testing"
 )
 
@@ -37,3 +42,59 @@ func TestRequest_ResolveURL(t *testing.T) {
 	}
 }
 
+func TestRequest_Do_RetriesOnAuthChallenge(t *testing.T) {
+	var calls atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if r.Header.Get("Authorization") != "Bearer fresh" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	token := "stale"
+	r := &Request{
+		HTTPClient: srv.Client(),
+		Method:     http.MethodGet,
+		RawURL:     srv.URL,
+		Auth: func(req *http.Request) error {
+			req.Header.Set("Authorization", "Bearer "+token)
+			return nil
+		},
+		AuthChallenge: func(req *http.Request, resp *http.Response) (bool, error) {
+			token = "fresh"
+			return true, nil
+		},
+	}
+
+	resp, err := r.Do()
+	if err != nil {
+		t.Fatalf("Do() err=%v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200 after retry; got=%d", resp.StatusCode)
+	}
+	if calls.Load() != 2 {
+		t.Fatalf("expected 2 calls; got=%d", calls.Load())
+	}
+}
+
+func TestBodyBytes(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodPost, "http://example.com", strings.NewReader("payload"))
+	b, err := BodyBytes(req)
+	if err != nil || string(b) != "payload" {
+		t.Fatalf("BodyBytes() = %q, %v", b, err)
+	}
+	// O body original continua disponível para envio.
+	again, _ := io.ReadAll(req.Body)
+	if string(again) != "payload" {
+		t.Fatalf("body consumed by BodyBytes: %q", again)
+	}
+
+	empty, _ := http.NewRequest(http.MethodGet, "http://example.com", nil)
+	if b, err := BodyBytes(empty); b != nil || err != nil {
+		t.Fatalf("expected nil body; got %q, %v", b, err)
+	}
+}
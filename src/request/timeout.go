@@ -0,0 +1,218 @@
+package request
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+	"time"
+
+	goxios_errors "github.com/drummerzzz/goxios/src/errors"
+)
+
+// PhaseTimeouts limita fases específicas da request. Zero desabilita a fase.
+type PhaseTimeouts struct {
+	// Dial limita a abertura da conexão TCP (sem DNS).
+	Dial time.Duration
+	// TLSHandshake limita o handshake TLS.
+	TLSHandshake time.Duration
+	// FirstByte limita a espera pelo primeiro byte da resposta depois do envio da request.
+	FirstByte time.Duration
+	// BodyIdle limita o tempo sem receber dados durante a leitura do body, sem limitar a
+	// duração total (ex: downloads longos em streaming).
+	BodyIdle time.Duration
+}
+
+func (p PhaseTimeouts) enabled() bool {
+	return p.Dial > 0 || p.TLSHandshake > 0 || p.FirstByte > 0 || p.BodyIdle > 0
+}
+
+// Timeout define o tempo total dessa request, incluindo a leitura do body, no lugar do
+// timeout do client. Zero remove o limite total (ex: streaming); use BodyIdleTimeout para
+// ainda detectar conexões paradas.
+func (r *Request) Timeout(d time.Duration) *Request {
+	if r == nil {
+		return r
+	}
+	r.RequestTimeout = &d
+	return r
+}
+
+// DialTimeout limita a abertura da conexão TCP dessa request.
+func (r *Request) DialTimeout(d time.Duration) *Request {
+	if r == nil {
+		return r
+	}
+	r.Phases.Dial = d
+	return r
+}
+
+// TLSHandshakeTimeout limita o handshake TLS dessa request.
+func (r *Request) TLSHandshakeTimeout(d time.Duration) *Request {
+	if r == nil {
+		return r
+	}
+	r.Phases.TLSHandshake = d
+	return r
+}
+
+// FirstByteTimeout limita a espera pelo primeiro byte da resposta (time-to-first-byte).
+func (r *Request) FirstByteTimeout(d time.Duration) *Request {
+	if r == nil {
+		return r
+	}
+	r.Phases.FirstByte = d
+	return r
+}
+
+// BodyIdleTimeout falha a leitura do body quando nenhum dado chega nesse intervalo.
+func (r *Request) BodyIdleTimeout(d time.Duration) *Request {
+	if r == nil {
+		return r
+	}
+	r.Phases.BodyIdle = d
+	return r
+}
+
+// validateTimeouts rejeita durações negativas.
+func (r *Request) validateTimeouts() error {
+	p := r.Phases
+	if (r.RequestTimeout != nil && *r.RequestTimeout < 0) ||
+		p.Dial < 0 || p.TLSHandshake < 0 || p.FirstByte < 0 || p.BodyIdle < 0 {
+		return goxios_errors.ErrInvalidTimeout
+	}
+	return nil
+}
+
+// httpClientFor retorna o http.Client da request: o do client, ou uma cópia dele com o
+// transport e o timeout próprios da request.
+func (r *Request) httpClientFor(tr *http.Transport) *http.Client {
+	if tr == r.Transport && r.RequestTimeout == nil {
+		return r.HTTPClient
+	}
+	hc := *r.HTTPClient
+	if tr != r.Transport {
+		hc.Transport = tr
+	}
+	if r.RequestTimeout != nil {
+		hc.Timeout = *r.RequestTimeout
+	}
+	return &hc
+}
+
+// phaseWatcher cancela o contexto da request quando uma fase excede o limite, com o erro
+// da fase como causa.
+type phaseWatcher struct {
+	limits PhaseTimeouts
+	cancel context.CancelCauseFunc
+
+	mu     sync.Mutex
+	timers map[error]*time.Timer
+}
+
+// withPhaseTimeouts instrumenta ctx com httptrace para aplicar os limites por fase.
+func withPhaseTimeouts(ctx context.Context, limits PhaseTimeouts) (context.Context, *phaseWatcher) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	w := &phaseWatcher{limits: limits, cancel: cancel, timers: make(map[error]*time.Timer)}
+
+	trace := &httptrace.ClientTrace{}
+	if limits.Dial > 0 {
+		trace.ConnectStart = func(_, _ string) { w.start(goxios_errors.ErrDialTimeout, limits.Dial) }
+		trace.ConnectDone = func(_, _ string, _ error) { w.stop(goxios_errors.ErrDialTimeout) }
+	}
+	if limits.TLSHandshake > 0 {
+		trace.TLSHandshakeStart = func() { w.start(goxios_errors.ErrTLSHandshakeTimeout, limits.TLSHandshake) }
+		trace.TLSHandshakeDone = func(tls.ConnectionState, error) { w.stop(goxios_errors.ErrTLSHandshakeTimeout) }
+	}
+	if limits.FirstByte > 0 {
+		trace.WroteRequest = func(httptrace.WroteRequestInfo) {
+			w.start(goxios_errors.ErrFirstByteTimeout, limits.FirstByte)
+		}
+		trace.GotFirstResponseByte = func() { w.stop(goxios_errors.ErrFirstByteTimeout) }
+	}
+	return httptrace.WithClientTrace(ctx, trace), w
+}
+
+func (w *phaseWatcher) start(phase error, d time.Duration) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if t, ok := w.timers[phase]; ok {
+		t.Reset(d)
+		return
+	}
+	w.timers[phase] = time.AfterFunc(d, func() { w.cancel(phase) })
+}
+
+func (w *phaseWatcher) stop(phase error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if t, ok := w.timers[phase]; ok {
+		t.Stop()
+	}
+}
+
+// done para os timers e libera o contexto.
+func (w *phaseWatcher) done() {
+	w.mu.Lock()
+	for _, t := range w.timers {
+		t.Stop()
+	}
+	w.mu.Unlock()
+	w.cancel(nil)
+}
+
+// wrapErr troca o erro genérico de cancelamento pelo erro da fase que estourou.
+func (w *phaseWatcher) wrapErr(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+	cause := context.Cause(ctx)
+	if isPhaseTimeout(cause) {
+		return errors.Join(cause, err)
+	}
+	return err
+}
+
+func isPhaseTimeout(err error) bool {
+	return errors.Is(err, goxios_errors.ErrDialTimeout) ||
+		errors.Is(err, goxios_errors.ErrTLSHandshakeTimeout) ||
+		errors.Is(err, goxios_errors.ErrFirstByteTimeout) ||
+		errors.Is(err, goxios_errors.ErrBodyReadTimeout)
+}
+
+// watchedBody aplica o BodyIdle na leitura e libera o watcher ao fechar o body.
+type watchedBody struct {
+	io.ReadCloser
+	ctx     context.Context
+	watcher *phaseWatcher
+	idle    time.Duration
+	once    sync.Once
+}
+
+func newWatchedBody(ctx context.Context, body io.ReadCloser, w *phaseWatcher) *watchedBody {
+	b := &watchedBody{ReadCloser: body, ctx: ctx, watcher: w, idle: w.limits.BodyIdle}
+	if b.idle > 0 {
+		w.start(goxios_errors.ErrBodyReadTimeout, b.idle)
+	}
+	return b
+}
+
+func (b *watchedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if b.idle > 0 && n > 0 {
+		b.watcher.start(goxios_errors.ErrBodyReadTimeout, b.idle)
+	}
+	if err != nil && err != io.EOF {
+		err = b.watcher.wrapErr(b.ctx, err)
+	}
+	return n, err
+}
+
+func (b *watchedBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.watcher.done)
+	return err
+}
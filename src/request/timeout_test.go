@@ -0,0 +1,113 @@
+package request
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	goxios_errors "github.com/drummerzzz/goxios/src/errors"
+)
+
+func TestRequest_Timeout_OverridesClientTimeout(t *testing.T) {
+	slowStarted := make(chan struct{})
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow" {
+			close(slowStarted)
+			<-release
+			return
+		}
+		// Bloqueia até o timeout do client cancelar a request.
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+
+	client := srv.Client()
+	client.Timeout = 20 * time.Millisecond
+	newReq := func(path string) *Request {
+		return &Request{HTTPClient: client, Method: http.MethodGet, RawURL: srv.URL + path}
+	}
+
+	slow := make(chan error, 1)
+	go func() {
+		resp, err := newReq("/slow").Timeout(time.Minute).Do()
+		if err == nil {
+			resp.Body.Close()
+		}
+		slow <- err
+	}()
+	<-slowStarted
+
+	// A request de controle começa depois da lenta e falha pelo timeout do client; só então
+	// a lenta é liberada, já tendo passado do timeout do client.
+	if _, err := newReq("/control").Do(); err == nil {
+		t.Fatalf("expected client timeout")
+	}
+	close(release)
+	if err := <-slow; err != nil {
+		t.Fatalf("Do() with longer request timeout err=%v", err)
+	}
+	if client.Timeout != 20*time.Millisecond {
+		t.Fatalf("request timeout must not change the shared client; got=%v", client.Timeout)
+	}
+
+	if _, err := newReq("/").Timeout(-time.Second).Do(); !errors.Is(err, goxios_errors.ErrInvalidTimeout) {
+		t.Fatalf("expected ErrInvalidTimeout; got=%v", err)
+	}
+}
+
+func TestRequest_FirstByteTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+
+	r := &Request{HTTPClient: srv.Client(), Method: http.MethodGet, RawURL: srv.URL}
+	_, err := r.FirstByteTimeout(20 * time.Millisecond).Do()
+	if !errors.Is(err, goxios_errors.ErrFirstByteTimeout) {
+		t.Fatalf("expected ErrFirstByteTimeout; got=%v", err)
+	}
+}
+
+func TestRequest_BodyIdleTimeout(t *testing.T) {
+	next := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		flusher := w.(http.Flusher)
+		// Cada chunk só é enviado depois que o teste leu o anterior; depois o servidor para.
+		for i := 0; i < 3; i++ {
+			w.Write([]byte("chunk"))
+			flusher.Flush()
+			select {
+			case <-next:
+			case <-r.Context().Done():
+				return
+			}
+		}
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+
+	// Um timeout total que já expirou prova que Timeout(0) o remove.
+	client := srv.Client()
+	client.Timeout = time.Nanosecond
+	r := &Request{HTTPClient: client, Method: http.MethodGet, RawURL: srv.URL}
+	resp, err := r.Timeout(0).BodyIdleTimeout(500 * time.Millisecond).Do()
+	if err != nil {
+		t.Fatalf("Do() err=%v", err)
+	}
+	defer resp.Body.Close()
+
+	buf := make([]byte, len("chunk"))
+	for i := 0; i < 3; i++ {
+		if _, err := io.ReadFull(resp.Body, buf); err != nil || string(buf) != "chunk" {
+			t.Fatalf("chunk %d: got %q err=%v", i, buf, err)
+		}
+		next <- struct{}{}
+	}
+	if _, err := io.ReadAll(resp.Body); !errors.Is(err, goxios_errors.ErrBodyReadTimeout) {
+		t.Fatalf("expected ErrBodyReadTimeout; got=%v", err)
+	}
+}
@@ -0,0 +1,311 @@
+package goxios
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/drummerzzz/goxios/internal/tlsutil"
+	goxios_errors "github.com/drummerzzz/goxios/src/errors"
+	"go.uber.org/zap"
+)
+
+// WithRootCAsFromPEM confia nos CAs informados (PEM) para validar os servidores.
+// Por padrão os CAs substituem o pool do sistema; use WithSystemRootCAs para adicioná-los a ele.
+// Pode ser combinado com as opções de mTLS.
+func WithRootCAsFromPEM(pemData []byte) Option {
+	return func(c *Client) error {
+		c.rootCAs = append(c.rootCAs, pemData)
+		return applyRootCAs(c)
+	}
+}
+
+// WithRootCAsFromFile confia nos CAs do arquivo PEM informado (ver WithRootCAsFromPEM).
+func WithRootCAsFromFile(caFile string) Option {
+	return func(c *Client) error {
+		pemData, err := os.ReadFile(caFile)
+		if err != nil {
+			return err
+		}
+		return WithRootCAsFromPEM(pemData)(c)
+	}
+}
+
+// WithSystemRootCAs mantém o pool de CAs do sistema e adiciona a ele os CAs de
+// WithRootCAsFromPEM/WithRootCAsFromFile, independente da ordem das opções.
+func WithSystemRootCAs() Option {
+	return func(c *Client) error {
+		c.useSystemRoots = true
+		return applyRootCAs(c)
+	}
+}
+
+// WithMTLSFromPKCS12 usa como certificado de cliente a identidade do bundle PKCS#12
+// (.p12/.pfx) protegido por password. Os intermediários do bundle são enviados no handshake.
+func WithMTLSFromPKCS12(data []byte, password string) Option {
+	return func(c *Client) error {
+		if len(data) == 0 {
+			return goxios_errors.ErrEmptyCertificate
+		}
+		cert, err := tlsutil.LoadPKCS12(data, password)
+		if err != nil {
+			return err
+		}
+		c.transport.TLSClientConfig = tlsutil.WithClientCertificate(c.transport.TLSClientConfig, cert)
+		return nil
+	}
+}
+
+// WithMTLSFromPKCS12File carrega o bundle PKCS#12 do arquivo (ver WithMTLSFromPKCS12).
+func WithMTLSFromPKCS12File(file, password string) Option {
+	return func(c *Client) error {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		return WithMTLSFromPKCS12(data, password)(c)
+	}
+}
+
+// WithMTLSFromEncryptedFile carrega o certificado de cliente como WithMTLSFromFile, com a
+// chave privada em PKCS#8 criptografado ("ENCRYPTED PRIVATE KEY") decifrada por password.
+// certFile pode conter a cadeia completa (leaf seguido dos intermediários).
+func WithMTLSFromEncryptedFile(certFile, keyFile, password string) Option {
+	return func(c *Client) error {
+		if certFile == "" || keyFile == "" {
+			return goxios_errors.ErrEmptyCertificate
+		}
+		certPEM, err := os.ReadFile(certFile)
+		if err != nil {
+			return err
+		}
+		keyPEM, err := os.ReadFile(keyFile)
+		if err != nil {
+			return err
+		}
+		cert, err := tlsutil.ParseKeyPair(certPEM, keyPEM, password)
+		if err != nil {
+			return err
+		}
+		c.transport.TLSClientConfig = tlsutil.WithClientCertificate(c.transport.TLSClientConfig, cert)
+		return nil
+	}
+}
+
+// WithMTLSIntermediatesFromFile adiciona os certificados intermediários do arquivo PEM à
+// cadeia do certificado de cliente. Deve vir depois da opção de mTLS.
+func WithMTLSIntermediatesFromFile(chainFile string) Option {
+	return func(c *Client) error {
+		cfg := c.transport.TLSClientConfig
+		if cfg == nil || len(cfg.Certificates) == 0 {
+			return goxios_errors.ErrEmptyCertificate
+		}
+		chainPEM, err := os.ReadFile(chainFile)
+		if err != nil {
+			return err
+		}
+		cert := cfg.Certificates[0]
+		cert.Certificate = append([][]byte(nil), cert.Certificate...)
+		if err := tlsutil.AppendIntermediates(&cert, chainPEM); err != nil {
+			return err
+		}
+		c.transport.TLSClientConfig = tlsutil.WithClientCertificate(cfg, cert)
+		return nil
+	}
+}
+
+// WithMTLSFromFileReload carrega o certificado de cliente como WithMTLSFromFile e verifica os
+// arquivos a cada interval, trocando o certificado usado em novos handshakes sem recriar o
+// client (ex: certificados de curta duração emitidos pelo cert-manager/Vault).
+// Falhas de recarga são registradas no logger e o certificado anterior é mantido.
+// Chame Client.Close para encerrar a verificação.
+func WithMTLSFromFileReload(certFile, keyFile string, interval time.Duration) Option {
+	return func(c *Client) error {
+		if certFile == "" || keyFile == "" {
+			return goxios_errors.ErrEmptyCertificate
+		}
+		if interval <= 0 {
+			interval = time.Minute
+		}
+		reloader, err := tlsutil.NewCertReloader(certFile, keyFile, func(err error) {
+			c.logger.Warn("goxios tls: failed to reload client certificate",
+				zap.String("cert_file", certFile),
+				zap.Error(err),
+			)
+		})
+		if err != nil {
+			return err
+		}
+		cfg := ensureTLSConfig(c)
+		cfg.Certificates = nil
+		cfg.GetClientCertificate = reloader.GetClientCertificate
+		// A verificação só começa no fim do New, para não vazar se uma opção seguinte falhar.
+		c.starters = append(c.starters, func() { reloader.Start(interval) })
+		c.closers = append(c.closers, reloader.Stop)
+		return nil
+	}
+}
+
+// WithInsecureSkipVerify desabilita a verificação do certificado do servidor.
+// Use apenas em desenvolvimento: o client registra um aviso no logger ao ser criado.
+func WithInsecureSkipVerify() Option {
+	return func(c *Client) error {
+		ensureTLSConfig(c).InsecureSkipVerify = true //nolint:gosec
+		return nil
+	}
+}
+
+// WithProxyCAFromPEM confia na CA de um proxy que intercepta TLS, mantendo o pool do sistema
+// para os demais servidores.
+func WithProxyCAFromPEM(pemData []byte) Option {
+	return func(c *Client) error {
+		c.useSystemRoots = true
+		return WithRootCAsFromPEM(pemData)(c)
+	}
+}
+
+// WithProxyCAFromFile confia na CA de um proxy que intercepta TLS (ver WithProxyCAFromPEM).
+func WithProxyCAFromFile(caFile string) Option {
+	return func(c *Client) error {
+		c.useSystemRoots = true
+		return WithRootCAsFromFile(caFile)(c)
+	}
+}
+
+// WithLegacyProxyInsecure restaura o comportamento antigo de WithProxyURL, que desabilitava
+// a verificação TLS quando nenhuma configuração TLS era definida.
+//
+// Deprecated: use WithProxyCAFromFile/WithProxyCAFromPEM para confiar na CA do proxy,
+// ou WithInsecureSkipVerify explicitamente.
+func WithLegacyProxyInsecure() Option {
+	return func(c *Client) error {
+		c.legacyProxyInsecure = true
+		return nil
+	}
+}
+
+type certificatePins struct {
+	hashes     []string
+	reportOnly bool
+}
+
+// WithCertificatePins exige que a cadeia do servidor em host contenha uma chave pública
+// com um dos hashes sha256 SPKI informados (base64, com ou sem prefixo "sha256/").
+// Informe também pins de backup para permitir a rotação de chaves do servidor.
+// host deve ser um nome DNS sem porta: IPs não são enviados no SNI e não podem ser
+// verificados. Para conectar por IP, use WithServerName ou WithResolveOverride.
+func WithCertificatePins(host string, sha256SPKIHashes ...string) Option {
+	return certificatePinsOption(host, sha256SPKIHashes, false)
+}
+
+// WithCertificatePinsReportOnly valida os pins como WithCertificatePins, mas apenas registra
+// divergências no logger do client sem falhar a conexão.
+func WithCertificatePinsReportOnly(host string, sha256SPKIHashes ...string) Option {
+	return certificatePinsOption(host, sha256SPKIHashes, true)
+}
+
+func certificatePinsOption(host string, hashes []string, reportOnly bool) Option {
+	return func(c *Client) error {
+		if host == "" {
+			return goxios_errors.ErrEmptyHost
+		}
+		if len(hashes) == 0 {
+			return goxios_errors.ErrEmptyPins
+		}
+		// Os pins são buscados pelo SNI, que nunca contém porta nem IP.
+		if strings.Contains(host, ":") || net.ParseIP(host) != nil {
+			return goxios_errors.ErrInvalidAddress
+		}
+		if c.pins == nil {
+			c.pins = make(map[string]certificatePins)
+		}
+		c.pins[strings.ToLower(host)] = certificatePins{hashes: hashes, reportOnly: reportOnly}
+		ensureTLSConfig(c).VerifyConnection = c.verifyPins
+		return nil
+	}
+}
+
+// verifyPins roda depois da verificação padrão da cadeia (tls.Config.VerifyConnection).
+func (c *Client) verifyPins(cs tls.ConnectionState) error {
+	host := strings.ToLower(cs.ServerName)
+	pins, ok := c.pins[host]
+	if !ok {
+		return nil
+	}
+
+	// Basta uma cadeia válida conter o pin: o servidor pode ser verificado por mais de um
+	// caminho (ex: CA cruzada) e a ordem das cadeias não é garantida.
+	chains := cs.VerifiedChains
+	if len(chains) == 0 {
+		chains = [][]*x509.Certificate{cs.PeerCertificates}
+	}
+	var got []string
+	seen := make(map[string]bool)
+	for _, chain := range chains {
+		if tlsutil.MatchPins(chain, pins.hashes) {
+			return nil
+		}
+		for _, cert := range chain {
+			if h := tlsutil.SPKIHash(cert); !seen[h] {
+				seen[h] = true
+				got = append(got, h)
+			}
+		}
+	}
+	if pins.reportOnly {
+		c.logger.Warn("goxios tls: certificate pin mismatch (report-only)",
+			zap.String("host", host),
+			zap.Strings("chain_spki_sha256", got),
+		)
+		return nil
+	}
+	c.logger.Error("goxios tls: certificate pin mismatch",
+		zap.String("host", host),
+		zap.Strings("chain_spki_sha256", got),
+	)
+	return goxios_errors.ErrCertificatePinMismatch
+}
+
+// applyInsecureTLS aplica o modo legado de proxy e avisa quando a verificação TLS está desabilitada
+// ou os segredos de sessão estão sendo gravados.
+// Roda depois de todas as opções para usar o logger configurado.
+func applyInsecureTLS(c *Client) {
+	if c.legacyProxyInsecure && c.transport.Proxy != nil && c.transport.TLSClientConfig == nil {
+		c.logger.Warn("goxios: legacy proxy mode disables TLS verification; " +
+			"this behavior is deprecated, trust the proxy CA with WithProxyCAFromFile instead")
+		ensureTLSConfig(c).InsecureSkipVerify = true //nolint:gosec
+	}
+	if cfg := c.transport.TLSClientConfig; cfg != nil && cfg.InsecureSkipVerify {
+		c.logger.Warn("goxios: TLS certificate verification is DISABLED (InsecureSkipVerify); " +
+			"connections are vulnerable to man-in-the-middle attacks")
+	}
+	if cfg := c.transport.TLSClientConfig; cfg != nil && cfg.KeyLogWriter != nil {
+		c.logger.Warn("goxios: TLS key logging is enabled (KeyLogWriter); " +
+			"session secrets are being written and traffic can be decrypted")
+	}
+}
+
+// applyRootCAs recria o pool a partir dos CAs acumulados nas opções.
+func applyRootCAs(c *Client) error {
+	if len(c.rootCAs) == 0 {
+		// Sem CAs customizados o pool do sistema já é o default do Go.
+		return nil
+	}
+	pool, err := tlsutil.LoadCertPool(c.useSystemRoots, c.rootCAs...)
+	if err != nil {
+		return err
+	}
+	ensureTLSConfig(c).RootCAs = pool
+	return nil
+}
+
+// ensureTLSConfig retorna a tls.Config do transport, criando-a se necessário.
+func ensureTLSConfig(c *Client) *tls.Config {
+	if c.transport.TLSClientConfig == nil {
+		c.transport.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	return c.transport.TLSClientConfig
+}
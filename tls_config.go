@@ -0,0 +1,116 @@
+package goxios
+
+import (
+	"crypto/tls"
+	"io"
+
+	goxios_errors "github.com/drummerzzz/goxios/src/errors"
+)
+
+// WithTLSMinVersion define a versão mínima de TLS (ex: tls.VersionTLS13). Default: TLS 1.2.
+func WithTLSMinVersion(version uint16) Option {
+	return func(c *Client) error {
+		if !validTLSVersion(version) {
+			return goxios_errors.ErrInvalidTLSVersion
+		}
+		cfg := ensureTLSConfig(c)
+		if cfg.MaxVersion != 0 && version > cfg.MaxVersion {
+			return goxios_errors.ErrInvalidTLSVersion
+		}
+		cfg.MinVersion = version
+		return nil
+	}
+}
+
+// WithTLSMaxVersion define a versão máxima de TLS (ex: tls.VersionTLS12 para servidores
+// com implementações de TLS 1.3 problemáticas).
+func WithTLSMaxVersion(version uint16) Option {
+	return func(c *Client) error {
+		if !validTLSVersion(version) {
+			return goxios_errors.ErrInvalidTLSVersion
+		}
+		cfg := ensureTLSConfig(c)
+		if cfg.MinVersion != 0 && version < cfg.MinVersion {
+			return goxios_errors.ErrInvalidTLSVersion
+		}
+		cfg.MaxVersion = version
+		return nil
+	}
+}
+
+// WithCipherSuites restringe as cipher suites de TLS 1.0–1.2 (ex: tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256).
+// As suites de TLS 1.3 não são configuráveis no Go.
+func WithCipherSuites(ids ...uint16) Option {
+	return func(c *Client) error {
+		known := make(map[uint16]bool)
+		for _, s := range append(tls.CipherSuites(), tls.InsecureCipherSuites()...) {
+			for _, v := range s.SupportedVersions {
+				if v != tls.VersionTLS13 {
+					known[s.ID] = true
+				}
+			}
+		}
+		for _, id := range ids {
+			if !known[id] {
+				return goxios_errors.ErrUnsupportedCipherSuite
+			}
+		}
+		ensureTLSConfig(c).CipherSuites = append([]uint16(nil), ids...)
+		return nil
+	}
+}
+
+// WithCurvePreferences define as curvas aceitas na troca de chaves, em ordem de preferência
+// (ex: tls.X25519, tls.CurveP256).
+func WithCurvePreferences(curves ...tls.CurveID) Option {
+	return func(c *Client) error {
+		ensureTLSConfig(c).CurvePreferences = append([]tls.CurveID(nil), curves...)
+		return nil
+	}
+}
+
+// WithALPN define os protocolos anunciados via ALPN (ex: "h2", "http/1.1").
+func WithALPN(protocols ...string) Option {
+	return func(c *Client) error {
+		ensureTLSConfig(c).NextProtos = append([]string(nil), protocols...)
+		return nil
+	}
+}
+
+// WithServerName sobrescreve o nome enviado no SNI e usado na verificação do certificado
+// (ex: conectar por IP ou por um host interno a um servidor com certificado público).
+func WithServerName(name string) Option {
+	return func(c *Client) error {
+		if name == "" {
+			return goxios_errors.ErrEmptyHost
+		}
+		ensureTLSConfig(c).ServerName = name
+		return nil
+	}
+}
+
+// WithTLSSessionCache habilita a retomada de sessões TLS com um cache LRU de capacity
+// entradas (capacity <= 0 usa o default do Go), evitando handshakes completos em novas conexões.
+func WithTLSSessionCache(capacity int) Option {
+	return func(c *Client) error {
+		ensureTLSConfig(c).ClientSessionCache = tls.NewLRUClientSessionCache(capacity)
+		return nil
+	}
+}
+
+// WithTLSKeyLogWriter grava os segredos de sessão no formato NSS key log, para inspecionar o
+// tráfego no Wireshark. Use apenas para debug: o client registra um aviso no logger.
+func WithTLSKeyLogWriter(w io.Writer) Option {
+	return func(c *Client) error {
+		ensureTLSConfig(c).KeyLogWriter = w
+		return nil
+	}
+}
+
+func validTLSVersion(v uint16) bool {
+	switch v {
+	case tls.VersionTLS10, tls.VersionTLS11, tls.VersionTLS12, tls.VersionTLS13:
+		return true
+	}
+	return false
+}
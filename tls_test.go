@@ -0,0 +1,440 @@
+package goxios
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/drummerzzz/goxios/internal/tlsutil"
+	goxios_errors "github.com/drummerzzz/goxios/src/errors"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+func newTLSServer(t *testing.T) (*httptest.Server, []byte) {
+	t.Helper()
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	return srv, caPEM
+}
+
+func TestClient_WithRootCAs(t *testing.T) {
+	srv, caPEM := newTLSServer(t)
+
+	t.Run("UntrustedByDefault", func(t *testing.T) {
+		c, _ := New()
+		if _, err := c.Get(srv.URL).Do(); err == nil {
+			t.Fatal("expected certificate error for private CA")
+		}
+	})
+
+	t.Run("FromPEM", func(t *testing.T) {
+		c, err := New(WithRootCAsFromPEM(caPEM))
+		if err != nil {
+			t.Fatalf("New() errored: %v", err)
+		}
+		resp, err := c.Get(srv.URL).Do()
+		if err != nil {
+			t.Fatalf("Do() errored: %v", err)
+		}
+		if !resp.Ok() {
+			t.Fatalf("expected 2xx; got %d", resp.StatusCode)
+		}
+	})
+
+	t.Run("FromFileWithSystemPool", func(t *testing.T) {
+		caFile := filepath.Join(t.TempDir(), "ca.pem")
+		if err := os.WriteFile(caFile, caPEM, 0o600); err != nil {
+			t.Fatal(err)
+		}
+		c, err := New(WithRootCAsFromFile(caFile), WithSystemRootCAs())
+		if err != nil {
+			t.Fatalf("New() errored: %v", err)
+		}
+		if _, err := c.Get(srv.URL).Do(); err != nil {
+			t.Fatalf("Do() errored: %v", err)
+		}
+	})
+
+	t.Run("KeptWithMTLS", func(t *testing.T) {
+		c, err := New(
+			WithRootCAsFromPEM(caPEM),
+			WithMTLSFromFile("cmd/examples/auth/mtls/cert.pem", "cmd/examples/auth/mtls/key.pem"),
+		)
+		if err != nil {
+			t.Fatalf("New() errored: %v", err)
+		}
+		cfg := c.transport.TLSClientConfig
+		if cfg.RootCAs == nil || len(cfg.Certificates) != 1 {
+			t.Fatal("expected root CAs and client certificate in the same TLS config")
+		}
+	})
+
+	t.Run("InvalidPEM", func(t *testing.T) {
+		if _, err := New(WithRootCAsFromPEM([]byte("garbage"))); err == nil {
+			t.Fatal("expected error for invalid CA PEM")
+		}
+	})
+}
+
+func TestClient_WithMTLSFromFileReload(t *testing.T) {
+	_, caPEM := newTLSServer(t)
+	c, err := New(
+		WithRootCAsFromPEM(caPEM),
+		WithMTLSFromFileReload("cmd/examples/auth/mtls/cert.pem", "cmd/examples/auth/mtls/key.pem", time.Second),
+	)
+	if err != nil {
+		t.Fatalf("New() errored: %v", err)
+	}
+	defer c.Close()
+
+	cfg := c.transport.TLSClientConfig
+	if cfg.GetClientCertificate == nil || len(cfg.Certificates) != 0 {
+		t.Fatal("expected client certificate to be served by GetClientCertificate")
+	}
+	if cfg.RootCAs == nil {
+		t.Fatal("expected root CAs to be kept")
+	}
+	if cert, _ := cfg.GetClientCertificate(nil); cert == nil {
+		t.Fatal("expected loaded client certificate")
+	}
+
+	if _, err := New(WithMTLSFromFileReload("missing.pem", "missing-key.pem", time.Second)); err == nil {
+		t.Fatal("expected error for missing files")
+	}
+
+	pending := 0
+	_, err = New(
+		WithMTLSFromFileReload("cmd/examples/auth/mtls/cert.pem", "cmd/examples/auth/mtls/key.pem", time.Second),
+		func(c *Client) error {
+			pending = len(c.starters)
+			return errors.New("boom")
+		},
+	)
+	if err == nil || pending != 1 {
+		t.Fatalf("expected reload to start only after all options; got err=%v pending=%d", err, pending)
+	}
+}
+
+func TestClient_WithMTLSFromPKCS12(t *testing.T) {
+	pair, err := tls.LoadX509KeyPair("cmd/examples/auth/mtls/cert.pem", "cmd/examples/auth/mtls/key.pem")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := pkcs12.Modern.Encode(pair.PrivateKey, pair.Leaf, nil, "s3cret")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := New(WithMTLSFromPKCS12(data, "s3cret"))
+	if err != nil {
+		t.Fatalf("New() errored: %v", err)
+	}
+	if cfg := c.transport.TLSClientConfig; cfg == nil || len(cfg.Certificates) != 1 {
+		t.Fatal("expected client certificate from PKCS#12 bundle")
+	}
+
+	if _, err := New(WithMTLSFromPKCS12(data, "wrong")); !errors.Is(err, goxios_errors.ErrIncorrectKeyPassword) {
+		t.Fatalf("expected ErrIncorrectKeyPassword; got %v", err)
+	}
+}
+
+func TestClient_PerRequestMTLSReusesTransport(t *testing.T) {
+	var conns atomic.Int32
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) == 0 {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	srv.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	srv.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			conns.Add(1)
+		}
+	}
+	srv.StartTLS()
+	defer srv.Close()
+
+	certPEM, _ := os.ReadFile("cmd/examples/auth/mtls/cert.pem")
+	keyPEM, _ := os.ReadFile("cmd/examples/auth/mtls/key.pem")
+	cert := &Certificate{
+		MtlsCertBase64: base64.StdEncoding.EncodeToString(certPEM),
+		MtlsKeyBase64:  base64.StdEncoding.EncodeToString(keyPEM),
+	}
+
+	c, err := New(WithRootCAsFromPEM(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})))
+	if err != nil {
+		t.Fatalf("New() errored: %v", err)
+	}
+	defer c.Close()
+
+	for i := 0; i < 3; i++ {
+		resp, err := c.Get(srv.URL).MTLS(cert).Do()
+		if err != nil {
+			t.Fatalf("Do() errored: %v", err)
+		}
+		if !resp.Ok() {
+			t.Fatalf("expected 200; got %d", resp.StatusCode)
+		}
+	}
+
+	if n := conns.Load(); n != 1 {
+		t.Fatalf("expected a single reused connection; got %d", n)
+	}
+	if s := c.RequestTransportStats(); s.Size != 1 || s.Misses != 1 || s.Hits != 2 {
+		t.Fatalf("unexpected pool stats: %+v", s)
+	}
+
+	c.Close()
+	if s := c.RequestTransportStats(); s.Size != 0 {
+		t.Fatalf("expected empty pool after Close; got %+v", s)
+	}
+}
+
+func TestClient_ProxyKeepsTLSVerification(t *testing.T) {
+	c, err := New(WithProxyURL("http://proxy.local:3128"))
+	if err != nil {
+		t.Fatalf("New() errored: %v", err)
+	}
+	if cfg := c.transport.TLSClientConfig; cfg != nil && cfg.InsecureSkipVerify {
+		t.Fatal("proxy must not disable TLS verification")
+	}
+}
+
+func TestClient_InsecureSkipVerifyIsLogged(t *testing.T) {
+	core, logs := observer.New(zap.WarnLevel)
+
+	c, err := New(WithInsecureSkipVerify(), WithLogger(zap.New(core)))
+	if err != nil {
+		t.Fatalf("New() errored: %v", err)
+	}
+	if !c.transport.TLSClientConfig.InsecureSkipVerify {
+		t.Fatal("expected InsecureSkipVerify")
+	}
+	if logs.Len() != 1 {
+		t.Fatalf("expected 1 warning; got %d", logs.Len())
+	}
+}
+
+func TestClient_LegacyProxyInsecure(t *testing.T) {
+	core, logs := observer.New(zap.WarnLevel)
+
+	c, err := New(
+		WithLogger(zap.New(core)),
+		WithProxyURL("http://proxy.local:3128"),
+		WithLegacyProxyInsecure(),
+	)
+	if err != nil {
+		t.Fatalf("New() errored: %v", err)
+	}
+	if !c.transport.TLSClientConfig.InsecureSkipVerify {
+		t.Fatal("expected legacy mode to disable verification")
+	}
+	if logs.FilterMessageSnippet("deprecated").Len() != 1 {
+		t.Fatal("expected deprecation warning")
+	}
+
+	// Com CA do proxy configurada, o modo legado não desabilita a verificação.
+	_, caPEM := newTLSServer(t)
+	c, _ = New(WithProxyURL("http://proxy.local:3128"), WithProxyCAFromPEM(caPEM), WithLegacyProxyInsecure())
+	if c.transport.TLSClientConfig.InsecureSkipVerify {
+		t.Fatal("expected verification kept when proxy CA is trusted")
+	}
+}
+
+func TestClient_WithCertificatePins(t *testing.T) {
+	srv, caPEM := newTLSServer(t)
+	// O certificado do httptest vale para example.com; o SNI não é enviado para IPs.
+	host := "example.com"
+	addr := srv.Listener.Addr().String()
+	_, port, _ := net.SplitHostPort(addr)
+	target := "https://" + host + ":" + port
+	dialTestServer := WithResolveOverride(host+":"+port, addr)
+	pin := tlsutil.SPKIHash(srv.Certificate())
+	wrong := "sha256/AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA="
+
+	t.Run("Match", func(t *testing.T) {
+		c, _ := New(dialTestServer, WithRootCAsFromPEM(caPEM), WithCertificatePins(host, wrong, "sha256/"+pin))
+		if _, err := c.Get(target).Do(); err != nil {
+			t.Fatalf("Do() errored: %v", err)
+		}
+	})
+
+	t.Run("Mismatch", func(t *testing.T) {
+		c, _ := New(dialTestServer, WithRootCAsFromPEM(caPEM), WithCertificatePins(host, wrong))
+		_, err := c.Get(target).Do()
+		if !errors.Is(err, goxios_errors.ErrCertificatePinMismatch) {
+			t.Fatalf("expected ErrCertificatePinMismatch; got %v", err)
+		}
+	})
+
+	t.Run("ReportOnly", func(t *testing.T) {
+		core, logs := observer.New(zap.WarnLevel)
+		c, _ := New(
+			dialTestServer,
+			WithRootCAsFromPEM(caPEM),
+			WithCertificatePinsReportOnly(host, wrong),
+			WithLogger(zap.New(core)),
+		)
+		if _, err := c.Get(target).Do(); err != nil {
+			t.Fatalf("Do() errored: %v", err)
+		}
+		if logs.FilterMessageSnippet("pin mismatch").Len() != 1 {
+			t.Fatal("expected pin mismatch to be logged")
+		}
+	})
+
+	t.Run("KeptWithEmptyMTLS", func(t *testing.T) {
+		c, _ := New(
+			dialTestServer,
+			WithRootCAsFromPEM(caPEM),
+			WithCertificatePins(host, wrong),
+			WithMTLSFromFile("", ""),
+		)
+		_, err := c.Get(target).Do()
+		if !errors.Is(err, goxios_errors.ErrCertificatePinMismatch) {
+			t.Fatalf("expected root CAs and pins kept after empty mTLS; got %v", err)
+		}
+	})
+
+	t.Run("EnforcedWhenDialingIP", func(t *testing.T) {
+		// srv.URL aponta para 127.0.0.1; o pin vale pelo nome enviado no SNI.
+		c, _ := New(WithRootCAsFromPEM(caPEM), WithServerName(host), WithCertificatePins(host, wrong))
+		_, err := c.Get(srv.URL).Do()
+		if !errors.Is(err, goxios_errors.ErrCertificatePinMismatch) {
+			t.Fatalf("expected ErrCertificatePinMismatch; got %v", err)
+		}
+	})
+
+	t.Run("RejectsIPAndPortKeys", func(t *testing.T) {
+		for _, key := range []string{"127.0.0.1", "::1", "[::1]", host + ":" + port} {
+			if _, err := New(WithCertificatePins(key, wrong)); !errors.Is(err, goxios_errors.ErrInvalidAddress) {
+				t.Errorf("WithCertificatePins(%q): expected ErrInvalidAddress; got %v", key, err)
+			}
+		}
+	})
+
+	t.Run("AnyVerifiedChain", func(t *testing.T) {
+		leaf := &x509.Certificate{RawSubjectPublicKeyInfo: []byte("leaf")}
+		oldRoot := &x509.Certificate{RawSubjectPublicKeyInfo: []byte("old-root")}
+		newRoot := &x509.Certificate{RawSubjectPublicKeyInfo: []byte("new-root")}
+		c, _ := New(WithCertificatePins(host, "sha256/"+tlsutil.SPKIHash(newRoot)))
+		cs := tls.ConnectionState{
+			ServerName:       host,
+			PeerCertificates: []*x509.Certificate{leaf},
+			VerifiedChains:   [][]*x509.Certificate{{leaf, oldRoot}, {leaf, newRoot}},
+		}
+		if err := c.verifyPins(cs); err != nil {
+			t.Fatalf("expected pin matched in second chain; got %v", err)
+		}
+		cs.VerifiedChains = cs.VerifiedChains[:1]
+		if err := c.verifyPins(cs); !errors.Is(err, goxios_errors.ErrCertificatePinMismatch) {
+			t.Fatalf("expected ErrCertificatePinMismatch; got %v", err)
+		}
+	})
+
+	t.Run("OtherHostsUnaffected", func(t *testing.T) {
+		c, _ := New(dialTestServer, WithRootCAsFromPEM(caPEM), WithCertificatePins("pinned.example.com", wrong))
+		if _, err := c.Get(target).Do(); err != nil {
+			t.Fatalf("Do() errored: %v", err)
+		}
+	})
+}
+
+func TestClient_TLSConfigOptions(t *testing.T) {
+	srv, caPEM := newTLSServer(t)
+
+	t.Run("Applied", func(t *testing.T) {
+		c, err := New(
+			WithTLSMinVersion(tls.VersionTLS12),
+			WithTLSMaxVersion(tls.VersionTLS13),
+			WithCipherSuites(tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256),
+			WithCurvePreferences(tls.X25519, tls.CurveP256),
+			WithALPN("http/1.1"),
+			WithTLSSessionCache(16),
+		)
+		if err != nil {
+			t.Fatalf("New() errored: %v", err)
+		}
+		cfg := c.transport.TLSClientConfig
+		if cfg.MinVersion != tls.VersionTLS12 || cfg.MaxVersion != tls.VersionTLS13 ||
+			len(cfg.CipherSuites) != 1 || len(cfg.CurvePreferences) != 2 ||
+			len(cfg.NextProtos) != 1 || cfg.ClientSessionCache == nil {
+			t.Fatalf("unexpected TLS config: %+v", cfg)
+		}
+	})
+
+	t.Run("InvalidVersions", func(t *testing.T) {
+		if _, err := New(WithTLSMinVersion(0x0999)); !errors.Is(err, goxios_errors.ErrInvalidTLSVersion) {
+			t.Fatalf("expected ErrInvalidTLSVersion; got %v", err)
+		}
+		_, err := New(WithTLSMaxVersion(tls.VersionTLS12), WithTLSMinVersion(tls.VersionTLS13))
+		if !errors.Is(err, goxios_errors.ErrInvalidTLSVersion) {
+			t.Fatalf("expected ErrInvalidTLSVersion for min > max; got %v", err)
+		}
+	})
+
+	t.Run("InvalidCipherSuite", func(t *testing.T) {
+		for _, id := range []uint16{0xffff, tls.TLS_AES_128_GCM_SHA256} {
+			if _, err := New(WithCipherSuites(id)); !errors.Is(err, goxios_errors.ErrUnsupportedCipherSuite) {
+				t.Fatalf("expected ErrUnsupportedCipherSuite for %#x; got %v", id, err)
+			}
+		}
+	})
+
+	t.Run("MinVersionEnforced", func(t *testing.T) {
+		old := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		old.TLS = &tls.Config{MaxVersion: tls.VersionTLS12}
+		old.Config.ErrorLog = log.New(io.Discard, "", 0)
+		old.StartTLS()
+		defer old.Close()
+
+		caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: old.Certificate().Raw})
+		c, _ := New(WithRootCAsFromPEM(caPEM), WithTLSMinVersion(tls.VersionTLS13))
+		if _, err := c.Get(old.URL).Do(); err == nil {
+			t.Fatal("expected handshake failure against TLS 1.2 server")
+		}
+	})
+
+	t.Run("ServerNameOverride", func(t *testing.T) {
+		// O certificado do httptest é válido para example.com; a conexão é feita pelo IP.
+		c, _ := New(WithRootCAsFromPEM(caPEM), WithServerName("example.com"))
+		if _, err := c.Get(srv.URL).Do(); err != nil {
+			t.Fatalf("Do() errored: %v", err)
+		}
+	})
+
+	t.Run("KeyLogWriter", func(t *testing.T) {
+		core, logs := observer.New(zap.WarnLevel)
+		var keyLog bytes.Buffer
+		c, _ := New(WithRootCAsFromPEM(caPEM), WithTLSKeyLogWriter(&keyLog), WithLogger(zap.New(core)))
+		if _, err := c.Get(srv.URL).Do(); err != nil {
+			t.Fatalf("Do() errored: %v", err)
+		}
+		if keyLog.Len() == 0 {
+			t.Fatal("expected session secrets in key log")
+		}
+		if logs.FilterMessageSnippet("key logging").Len() != 1 {
+			t.Fatal("expected key logging warning")
+		}
+	})
+}
@@ -0,0 +1,192 @@
+package goxios
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/drummerzzz/goxios/internal/transportpool"
+	goxios_errors "github.com/drummerzzz/goxios/src/errors"
+)
+
+// WithMaxIdleConns limita o total de conexões ociosas mantidas pelo client (0 = sem limite).
+func WithMaxIdleConns(n int) Option {
+	return func(c *Client) error {
+		if n < 0 {
+			return goxios_errors.ErrInvalidPoolConfig
+		}
+		c.transport.MaxIdleConns = n
+		return nil
+	}
+}
+
+// WithMaxIdleConnsPerHost define quantas conexões ociosas manter por host. O default do Go
+// é 2, o que força novas conexões em workers com muitas requests concorrentes ao mesmo host.
+func WithMaxIdleConnsPerHost(n int) Option {
+	return func(c *Client) error {
+		if n < 0 {
+			return goxios_errors.ErrInvalidPoolConfig
+		}
+		c.transport.MaxIdleConnsPerHost = n
+		return nil
+	}
+}
+
+// WithMaxConnsPerHost limita as conexões (ativas + ociosas) por host; requests acima do
+// limite aguardam uma conexão livre. 0 = sem limite.
+func WithMaxConnsPerHost(n int) Option {
+	return func(c *Client) error {
+		if n < 0 {
+			return goxios_errors.ErrInvalidPoolConfig
+		}
+		c.transport.MaxConnsPerHost = n
+		return nil
+	}
+}
+
+// WithIdleConnTimeout define por quanto tempo uma conexão ociosa é mantida (0 = sem limite).
+func WithIdleConnTimeout(d time.Duration) Option {
+	return transportTimeout(func(c *Client, d time.Duration) { c.transport.IdleConnTimeout = d }, d)
+}
+
+// WithTLSHandshakeTimeout limita a duração do handshake TLS (0 = sem limite).
+func WithTLSHandshakeTimeout(d time.Duration) Option {
+	return transportTimeout(func(c *Client, d time.Duration) { c.transport.TLSHandshakeTimeout = d }, d)
+}
+
+// WithResponseHeaderTimeout limita a espera pelos headers da resposta depois que a request
+// foi enviada, sem limitar a leitura do body (0 = sem limite).
+func WithResponseHeaderTimeout(d time.Duration) Option {
+	return transportTimeout(func(c *Client, d time.Duration) { c.transport.ResponseHeaderTimeout = d }, d)
+}
+
+// WithExpectContinueTimeout define a espera pelo "100 Continue" em requests com
+// "Expect: 100-continue" antes de enviar o body (0 = envia imediatamente).
+func WithExpectContinueTimeout(d time.Duration) Option {
+	return transportTimeout(func(c *Client, d time.Duration) { c.transport.ExpectContinueTimeout = d }, d)
+}
+
+// WithDialTimeout limita a abertura de cada conexão TCP, sem contar o DNS (0 = sem limite).
+// Pode ser sobrescrito por request com Request.DialTimeout.
+func WithDialTimeout(d time.Duration) Option {
+	return transportTimeout(func(c *Client, d time.Duration) { c.phases.Dial = d }, d)
+}
+
+// WithBodyIdleTimeout falha a leitura do body quando nenhum dado chega nesse intervalo, sem
+// limitar a duração total do download (0 = sem limite). Pode ser sobrescrito por request
+// com Request.BodyIdleTimeout.
+func WithBodyIdleTimeout(d time.Duration) Option {
+	return transportTimeout(func(c *Client, d time.Duration) { c.phases.BodyIdle = d }, d)
+}
+
+// RequestTransportStats são as métricas do pool de transports próprios de requests
+// (Request.MTLS e Request.Proxy).
+type RequestTransportStats = transportpool.Stats
+
+// WithRequestTransportPool ajusta o pool de transports usado por Request.MTLS e Request.Proxy.
+// Cada certificado/proxy distinto ganha um transport próprio, reaproveitado entre requests
+// (keep-alive e retomada de sessão TLS). Acima de maxSize os menos usados são removidos, assim
+// como os ociosos por mais de idleTimeout. Defaults: 64 transports e 5 minutos.
+func WithRequestTransportPool(maxSize int, idleTimeout time.Duration) Option {
+	return func(c *Client) error {
+		if maxSize < 0 || idleTimeout < 0 {
+			return goxios_errors.ErrInvalidPoolConfig
+		}
+		c.transportPool.Close()
+		c.transportPool = transportpool.New(maxSize, idleTimeout)
+		return nil
+	}
+}
+
+// RequestTransportStats retorna as métricas do pool de transports por request.
+func (c *Client) RequestTransportStats() RequestTransportStats {
+	return c.transportPool.Stats()
+}
+
+func transportTimeout(set func(c *Client, d time.Duration), d time.Duration) Option {
+	return func(c *Client) error {
+		if d < 0 {
+			return goxios_errors.ErrInvalidTimeout
+		}
+		set(c, d)
+		return nil
+	}
+}
+
+// WithKeepAlives habilita ou desabilita o reaproveitamento de conexões HTTP. Desabilitado,
+// cada request abre uma conexão nova.
+func WithKeepAlives(enabled bool) Option {
+	return func(c *Client) error {
+		c.transport.DisableKeepAlives = !enabled
+		return nil
+	}
+}
+
+// WithTCPKeepAlive define o intervalo dos probes de keep-alive TCP. Valor negativo desabilita.
+func WithTCPKeepAlive(interval time.Duration) Option {
+	return func(c *Client) error {
+		netDialer(c).KeepAlive = interval
+		return nil
+	}
+}
+
+// WithHTTP2 habilita ou desabilita HTTP/2 sobre TLS. Por padrão o Go não tenta HTTP/2 quando
+// há configuração TLS customizada (ex: CAs, mTLS); WithHTTP2(true) força a negociação via ALPN.
+func WithHTTP2(enabled bool) Option {
+	return func(c *Client) error {
+		protocols(c).SetHTTP2(enabled)
+		return nil
+	}
+}
+
+// WithH2C usa HTTP/2 sem TLS com prior knowledge (h2c) em URLs http://, como em service
+// meshes. HTTP/1 fica desabilitado no client, então use um client dedicado para esses serviços;
+// URLs https:// continuam usando HTTP/2 sobre TLS.
+func WithH2C() Option {
+	return func(c *Client) error {
+		p := protocols(c)
+		p.SetHTTP1(false)
+		p.SetHTTP2(true)
+		p.SetUnencryptedHTTP2(true)
+		return nil
+	}
+}
+
+// WithHTTP2ReadIdleTimeout envia um ping de health check quando a conexão HTTP/2 fica esse
+// tempo sem receber frames, detectando conexões mortas (0 = desabilitado).
+func WithHTTP2ReadIdleTimeout(d time.Duration) Option {
+	return transportTimeout(func(c *Client, d time.Duration) { http2Config(c).SendPingTimeout = d }, d)
+}
+
+// WithHTTP2PingTimeout fecha a conexão HTTP/2 quando o ping de health check não é respondido
+// nesse tempo. Default do Go: 15s.
+func WithHTTP2PingTimeout(d time.Duration) Option {
+	return transportTimeout(func(c *Client, d time.Duration) { http2Config(c).PingTimeout = d }, d)
+}
+
+// WithHTTP2MaxReadFrameSize define o maior frame HTTP/2 aceito, entre 16KiB e 16MiB.
+func WithHTTP2MaxReadFrameSize(size int) Option {
+	return func(c *Client) error {
+		if size < 16<<10 || size > 16<<20 {
+			return goxios_errors.ErrInvalidHTTP2Config
+		}
+		http2Config(c).MaxReadFrameSize = size
+		return nil
+	}
+}
+
+// http2Config retorna a configuração HTTP/2 do transport, criando-a se necessário.
+func http2Config(c *Client) *http.HTTP2Config {
+	if c.transport.HTTP2 == nil {
+		c.transport.HTTP2 = &http.HTTP2Config{}
+	}
+	return c.transport.HTTP2
+}
+
+// protocols retorna os protocolos do transport, criando-os com HTTP/1 habilitado.
+func protocols(c *Client) *http.Protocols {
+	if c.transport.Protocols == nil {
+		c.transport.Protocols = new(http.Protocols)
+		c.transport.Protocols.SetHTTP1(true)
+	}
+	return c.transport.Protocols
+}
@@ -0,0 +1,158 @@
+package goxios
+
+import (
+	"encoding/pem"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	goxios_errors "github.com/drummerzzz/goxios/src/errors"
+)
+
+func TestClient_TransportOptions(t *testing.T) {
+	c, err := New(
+		WithMaxIdleConns(200),
+		WithMaxIdleConnsPerHost(50),
+		WithMaxConnsPerHost(100),
+		WithIdleConnTimeout(90*time.Second),
+		WithTLSHandshakeTimeout(5*time.Second),
+		WithResponseHeaderTimeout(10*time.Second),
+		WithExpectContinueTimeout(time.Second),
+		WithKeepAlives(false),
+		WithTCPKeepAlive(15*time.Second),
+		WithDialTimeout(3*time.Second),
+		WithBodyIdleTimeout(30*time.Second),
+	)
+	if err != nil {
+		t.Fatalf("New() errored: %v", err)
+	}
+	tr := c.transport
+	if tr.MaxIdleConns != 200 || tr.MaxIdleConnsPerHost != 50 || tr.MaxConnsPerHost != 100 ||
+		tr.IdleConnTimeout != 90*time.Second || tr.TLSHandshakeTimeout != 5*time.Second ||
+		tr.ResponseHeaderTimeout != 10*time.Second || tr.ExpectContinueTimeout != time.Second ||
+		!tr.DisableKeepAlives || c.netDialer.KeepAlive != 15*time.Second {
+		t.Fatalf("unexpected transport config: %+v", tr)
+	}
+	if p := c.Get("/").Phases; p.Dial != 3*time.Second || p.BodyIdle != 30*time.Second {
+		t.Fatalf("expected client phase timeouts on requests; got %+v", p)
+	}
+
+	if _, err := New(WithMaxIdleConnsPerHost(-1)); !errors.Is(err, goxios_errors.ErrInvalidPoolConfig) {
+		t.Fatalf("expected ErrInvalidPoolConfig; got %v", err)
+	}
+	if _, err := New(WithResponseHeaderTimeout(-time.Second)); !errors.Is(err, goxios_errors.ErrInvalidTimeout) {
+		t.Fatalf("expected ErrInvalidTimeout; got %v", err)
+	}
+}
+
+func TestClient_WithHTTP2(t *testing.T) {
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	srv.EnableHTTP2 = true
+	srv.StartTLS()
+	defer srv.Close()
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+
+	for _, enabled := range []bool{true, false} {
+		c, err := New(WithRootCAsFromPEM(caPEM), WithHTTP2(enabled))
+		if err != nil {
+			t.Fatalf("New() errored: %v", err)
+		}
+		resp, err := c.Get(srv.URL).Do()
+		if err != nil {
+			t.Fatalf("Do() errored: %v", err)
+		}
+		if got := resp.ProtoMajor == 2; got != enabled {
+			t.Fatalf("WithHTTP2(%v): got protocol %s", enabled, resp.Proto)
+		}
+		c.Close()
+	}
+}
+
+func TestClient_HTTP2KeptWithCustomDialer(t *testing.T) {
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	srv.EnableHTTP2 = true
+	srv.StartTLS()
+	defer srv.Close()
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	_, port, _ := net.SplitHostPort(srv.Listener.Addr().String())
+
+	c, err := New(
+		WithRootCAsFromPEM(caPEM),
+		WithResolveOverride("example.com:"+port, srv.Listener.Addr().String()),
+	)
+	if err != nil {
+		t.Fatalf("New() errored: %v", err)
+	}
+	defer c.Close()
+	resp, err := c.Get("https://example.com:" + port).Do()
+	if err != nil {
+		t.Fatalf("Do() errored: %v", err)
+	}
+	if resp.ProtoMajor != 2 {
+		t.Fatalf("expected HTTP/2 with resolve override; got %s", resp.Proto)
+	}
+}
+
+func TestClient_CloseClosesIdleConnections(t *testing.T) {
+	closed := make(chan struct{}, 1)
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	srv.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateClosed {
+			closed <- struct{}{}
+		}
+	}
+	srv.Start()
+	defer srv.Close()
+
+	c, _ := New()
+	if _, err := c.Get(srv.URL).Do(); err != nil {
+		t.Fatalf("Do() errored: %v", err)
+	}
+	c.Close()
+
+	select {
+	case <-closed:
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected idle connection to be closed")
+	}
+}
+
+func TestClient_WithH2C(t *testing.T) {
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Proto", r.Proto)
+	}))
+	srv.Config.Protocols = new(http.Protocols)
+	srv.Config.Protocols.SetHTTP1(true)
+	srv.Config.Protocols.SetUnencryptedHTTP2(true)
+	srv.Start()
+	defer srv.Close()
+
+	c, err := New(
+		WithH2C(),
+		WithHTTP2ReadIdleTimeout(30*time.Second),
+		WithHTTP2PingTimeout(5*time.Second),
+		WithHTTP2MaxReadFrameSize(1<<20),
+	)
+	if err != nil {
+		t.Fatalf("New() errored: %v", err)
+	}
+	defer c.Close()
+	resp, err := c.Get(srv.URL).Do()
+	if err != nil {
+		t.Fatalf("Do() errored: %v", err)
+	}
+	if got := resp.Header.Get("X-Proto"); got != "HTTP/2.0" {
+		t.Fatalf("expected h2c; got %s", got)
+	}
+	if cfg := c.transport.HTTP2; cfg.SendPingTimeout != 30*time.Second || cfg.PingTimeout != 5*time.Second ||
+		cfg.MaxReadFrameSize != 1<<20 {
+		t.Fatalf("unexpected HTTP/2 config: %+v", cfg)
+	}
+
+	if _, err := New(WithHTTP2MaxReadFrameSize(1024)); !errors.Is(err, goxios_errors.ErrInvalidHTTP2Config) {
+		t.Fatalf("expected ErrInvalidHTTP2Config; got %v", err)
+	}
+}